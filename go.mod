@@ -7,4 +7,6 @@ require (
 	github.com/go-redis/redis/v8 v8.11.0
 	github.com/hashicorp/go-retryablehttp v0.6.8
 	github.com/mailru/easyjson v0.7.7
+	golang.org/x/crypto v0.0.0-20211117183948-ae814b36b871
+	golang.org/x/net v0.0.0-20211112202133-69e39bad7dc2
 )
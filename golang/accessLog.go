@@ -0,0 +1,347 @@
+package golang
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserLogSink - destination of the access log records (memory, file, redis stream and so on)
+type UserLogSink interface {
+	WriteLogs(ctx context.Context, logs []UserLog) error
+}
+
+// AccessLogOptions - options of batching and backpressure for AccessLogger
+type AccessLogOptions struct {
+	QueueSize     int           // Размер очереди записей, которые ожидают отправки в sink
+	BatchSize     int           // Максимальное количество записей в одной пачке
+	FlushInterval time.Duration // Не полная пачка будет отправлена по истечении этого времени
+	BlockOnFull   bool          // Если true - запрос будет ждать освобождения очереди, иначе запись будет отброшена
+}
+
+// AccessLogger - collect UserLog records and send them to the sink by batches in background
+type AccessLogger struct {
+	sink    UserLogSink
+	opt     AccessLogOptions
+	queue   chan UserLog
+	done    chan struct{}
+	mt      sync.RWMutex
+	closed  bool
+	dropped uint64
+}
+
+// NewAccessLogger - create access logger and start background goroutine that flush records to sink
+func NewAccessLogger(sink UserLogSink, opt AccessLogOptions) *AccessLogger {
+	if opt.QueueSize <= 0 {
+		opt.QueueSize = 1024
+	}
+	if opt.BatchSize <= 0 {
+		opt.BatchSize = 64
+	}
+	if opt.FlushInterval <= 0 {
+		opt.FlushInterval = time.Second
+	}
+	al := &AccessLogger{
+		sink:  sink,
+		opt:   opt,
+		queue: make(chan UserLog, opt.QueueSize),
+		done:  make(chan struct{}),
+	}
+	go al.run()
+	return al
+}
+
+// Log - put record to the queue. Return false if record was dropped because queue is full or logger is closed
+func (al *AccessLogger) Log(rec UserLog) bool {
+	al.mt.RLock()
+	defer al.mt.RUnlock()
+	if al.closed {
+		atomic.AddUint64(&al.dropped, 1)
+		return false
+	}
+	if al.opt.BlockOnFull {
+		al.queue <- rec
+		return true
+	}
+	select {
+	case al.queue <- rec:
+		return true
+	default:
+		atomic.AddUint64(&al.dropped, 1)
+		return false
+	}
+}
+
+// Dropped - count of records dropped because of the full queue or closed logger
+func (al *AccessLogger) Dropped() uint64 {
+	return atomic.LoadUint64(&al.dropped)
+}
+
+// Close - flush all records from the queue and stop background goroutine
+func (al *AccessLogger) Close() {
+	al.mt.Lock()
+	if al.closed {
+		al.mt.Unlock()
+		return
+	}
+	al.closed = true
+	close(al.queue)
+	al.mt.Unlock()
+	<-al.done
+}
+
+func (al *AccessLogger) flush(batch []UserLog) {
+	if len(batch) == 0 {
+		return
+	}
+	if err := al.sink.WriteLogs(context.Background(), batch); err != nil {
+		GetLogger().Error(context.Background(), "access log flush failed", "records", len(batch), "error", err)
+	}
+}
+
+func (al *AccessLogger) run() {
+	defer close(al.done)
+	ticker := time.NewTicker(al.opt.FlushInterval)
+	defer ticker.Stop()
+	batch := make([]UserLog, 0, al.opt.BatchSize)
+	for {
+		select {
+		case rec, ok := <-al.queue:
+			if !ok {
+				al.flush(batch)
+				return
+			}
+			batch = append(batch, rec)
+			if len(batch) >= al.opt.BatchSize {
+				al.flush(batch)
+				batch = make([]UserLog, 0, al.opt.BatchSize)
+			}
+		case <-ticker.C:
+			al.flush(batch)
+			batch = make([]UserLog, 0, al.opt.BatchSize)
+		}
+	}
+}
+
+var trustedProxies atomic.Value
+
+func init() {
+	SetTrustedProxies("127.0.0.0/8", "::1/128", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7")
+}
+
+// SetTrustedProxies - networks (CIDR or single IP) of the proxies whose X-Forwarded-For and X-Real-Ip headers are trusted.
+// По умолчанию доверяем loopback и частным сетям. Без аргументов заголовки прокси не используются
+func SetTrustedProxies(networks ...string) error {
+	nets := make([]*net.IPNet, 0, len(networks))
+	for _, n := range networks {
+		if !strings.ContainsRune(n, '/') {
+			if ip := net.ParseIP(n); ip != nil && ip.To4() != nil {
+				n += "/32"
+			} else {
+				n += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(n)
+		if err != nil {
+			return err
+		}
+		nets = append(nets, ipNet)
+	}
+	trustedProxies.Store(nets)
+	return nil
+}
+
+func trustedProxy(addr string) bool {
+	ip := net.ParseIP(strings.TrimSpace(addr))
+	if ip == nil {
+		return false
+	}
+	nets, _ := trustedProxies.Load().([]*net.IPNet)
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP - address of the client. X-Forwarded-For and X-Real-Ip are used only if the request came from the trusted proxy
+// (see SetTrustedProxies): X-Forwarded-For is read from right to left up to the first not trusted address
+func clientIP(r *http.Request) string {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	if !trustedProxy(remote) {
+		return remote
+	}
+	if fwd := r.Header.Get("X-Forwarded-For"); len(fwd) != 0 {
+		hops := strings.Split(fwd, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if i == 0 || !trustedProxy(hop) {
+				return hop
+			}
+		}
+	}
+	if ip := r.Header.Get("X-Real-Ip"); len(ip) != 0 {
+		return strings.TrimSpace(ip)
+	}
+	return remote
+}
+
+// accessLogRequest - контекст запроса после ParseHeader. Middleware журнала стоит раньше ParseHeaderMiddleware
+// (чтобы записать и отклоненные им запросы) и не видит контекст, созданный внутри цепочки net/http
+type accessLogRequest struct {
+	ctx context.Context
+}
+
+type accessLogKey struct{}
+
+// noteAccessLogContext - remember parsed context of the request for access log middleware
+func noteAccessLogContext(ctx context.Context) {
+	if st, ok := ctx.Value(accessLogKey{}).(*accessLogRequest); ok {
+		st.ctx = ctx
+	}
+}
+
+// abortedStatus - request with this response status is marked as aborted in the access log
+func abortedStatus(status int) bool {
+	return status >= http.StatusBadRequest
+}
+
+// BuildUserLog - form UserLog for request. User and request ID are taken from context populated by ParseHeader
+func BuildUserLog(r *http.Request, ip string, isAborted bool, start time.Time) UserLog {
+	ctx := r.Context()
+	user, _ := ctx.Value(UserKey).(User)
+	reqID, _ := ctx.Value(RequestID).(string)
+	return UserLog{
+		UserID:       user.ID,
+		SessionKey:   user.SessionKey,
+		IP:           ip,
+		URL:          r.URL.RequestURI(),
+		Method:       r.Method,
+		IsAborted:    isAborted,
+		RequestID:    reqID,
+		UserAgent:    r.UserAgent(),
+		ResponceTime: time.Since(start),
+		AddeDate:     start,
+	}
+}
+
+// AccessLogMiddleware - gin middleware that write UserLog for each request.
+// Request with status code >= 400 is marked as aborted.
+// Place it before ParseHeaderMiddleware: requests rejected by ParseHeaderMiddleware are logged too, user is known for the others
+func AccessLogMiddleware(al *AccessLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		al.Log(BuildUserLog(c.Request, clientIP(c.Request), abortedStatus(c.Writer.Status()), start))
+	}
+}
+
+// statusWriter - remember status code of the response
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// AccessLogHTTPMiddleware - net/http middleware that write UserLog for each request.
+// Request with status code >= 400 is marked as aborted.
+// Wrap ParseHTTPHeaderMiddleware by it: requests rejected by ParseHTTPHeaderMiddleware are logged too, user is known for the others
+func AccessLogHTTPMiddleware(al *AccessLogger, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		st := &accessLogRequest{}
+		handler.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), accessLogKey{}, st)))
+		if st.ctx != nil {
+			r = r.WithContext(st.ctx)
+		}
+		al.Log(BuildUserLog(r, clientIP(r), abortedStatus(sw.status), start))
+	})
+}
+
+// RingBufferSink - in memory sink that store last N records
+type RingBufferSink struct {
+	mt    sync.Mutex
+	buf   []UserLog
+	next  int
+	count int
+}
+
+// NewRingBufferSink - create in memory sink with capacity size
+func NewRingBufferSink(size int) *RingBufferSink {
+	if size <= 0 {
+		size = 1
+	}
+	return &RingBufferSink{buf: make([]UserLog, size)}
+}
+
+func (s *RingBufferSink) WriteLogs(ctx context.Context, logs []UserLog) error {
+	s.mt.Lock()
+	defer s.mt.Unlock()
+	for i := range logs {
+		s.buf[s.next] = logs[i]
+		s.next = (s.next + 1) % len(s.buf)
+		if s.count < len(s.buf) {
+			s.count++
+		}
+	}
+	return nil
+}
+
+// Records - return stored records from the oldest to the newest
+func (s *RingBufferSink) Records() []UserLog {
+	s.mt.Lock()
+	defer s.mt.Unlock()
+	res := make([]UserLog, 0, s.count)
+	start := (s.next - s.count + len(s.buf)) % len(s.buf)
+	for i := 0; i < s.count; i++ {
+		res = append(res, s.buf[(start+i)%len(s.buf)])
+	}
+	return res
+}
+
+// FileSink - write records as JSON lines to the file with rotation by size
+type FileSink struct {
+	lines *RotatedFile
+}
+
+// NewFileSink - create JSON lines sink. When file size reach maxSize bytes it will be renamed to path.1 (path.1 -> path.2 ...)
+// maxBackups - how many rotated files will be kept
+func NewFileSink(path string, maxSize int64, maxBackups int) (*FileSink, error) {
+	f, err := OpenRotatedFile(path, maxSize, maxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{lines: f}, nil
+}
+
+func (s *FileSink) WriteLogs(ctx context.Context, logs []UserLog) error {
+	for i := range logs {
+		data, err := logs[i].MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if err = s.lines.WriteLine(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	return s.lines.Close()
+}
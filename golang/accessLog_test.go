@@ -0,0 +1,145 @@
+package golang
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAccessLoggerLogAfterClose(t *testing.T) {
+	sink := NewRingBufferSink(16)
+	al := NewAccessLogger(sink, AccessLogOptions{QueueSize: 4, BlockOnFull: true})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				al.Log(UserLog{URL: "/"})
+			}
+		}()
+	}
+	al.Close()
+	wg.Wait()
+	al.Close()
+	if al.Log(UserLog{}) {
+		t.Fatal("record accepted by closed logger")
+	}
+	if al.Dropped() == 0 {
+		t.Fatal("rejected record is not counted as dropped")
+	}
+}
+
+func TestAccessLogHTTPMiddlewareRejected(t *testing.T) {
+	sink := NewRingBufferSink(4)
+	al := NewAccessLogger(sink, AccessLogOptions{})
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	h := AccessLogHTTPMiddleware(al, ParseHTTPHeaderMiddleware(ok))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/forbidden", nil))
+
+	user := User{ID: 7}
+	userJSON, _ := user.MarshalJSON()
+	req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set(UserHeaderKey, string(userJSON))
+	req.Header.Set(SignatureHeaderKey, CreateSignature([]byte(os.Getenv(EgeonSecretKeyEnviron)), userJSON))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	al.Close()
+
+	recs := sink.Records()
+	if len(recs) != 2 {
+		t.Fatalf("got %d records", len(recs))
+	}
+	if !recs[0].IsAborted || recs[0].URL != "/forbidden" {
+		t.Errorf("rejected request: %+v", recs[0])
+	}
+	if recs[1].IsAborted || recs[1].UserID != 7 || len(recs[1].RequestID) == 0 {
+		t.Errorf("accepted request: %+v", recs[1])
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	defer SetTrustedProxies("127.0.0.0/8", "::1/128", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7")
+	if err := SetTrustedProxies("10.0.0.0/8", "192.168.1.1"); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		remote, fwd, real, want string
+	}{
+		{"8.8.8.8:1234", "1.1.1.1", "", "8.8.8.8"},
+		{"10.0.0.1:1234", "1.1.1.1", "", "1.1.1.1"},
+		{"10.0.0.1:1234", "6.6.6.6, 1.1.1.1, 10.0.0.2", "", "1.1.1.1"},
+		{"192.168.1.1:80", "10.0.0.3, 10.0.0.2", "", "10.0.0.3"},
+		{"10.0.0.1:1234", "", "2.2.2.2", "2.2.2.2"},
+		{"192.168.1.2:80", "", "2.2.2.2", "192.168.1.2"},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = c.remote
+		if len(c.fwd) != 0 {
+			r.Header.Set("X-Forwarded-For", c.fwd)
+		}
+		if len(c.real) != 0 {
+			r.Header.Set("X-Real-Ip", c.real)
+		}
+		if got := clientIP(r); got != c.want {
+			t.Errorf("%s %q %q: got %s, want %s", c.remote, c.fwd, c.real, got, c.want)
+		}
+	}
+	if SetTrustedProxies("not a network") == nil {
+		t.Error("bad network accepted")
+	}
+}
+
+func TestRotatedFileRenameFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "access.log")
+	rf, err := OpenRotatedFile(path, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+	// не пустой каталог на месте path.1 - переименование завершится ошибкой
+	if err = os.MkdirAll(filepath.Join(path+".1", "busy"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err = rf.WriteLine([]byte("0123456789")); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Count(string(data), "\n") != 3 {
+		t.Fatalf("lines lost: %q", data)
+	}
+	rf.Close()
+	if rf.WriteLine([]byte("x")) != ErrFileClosed {
+		t.Fatal("write to closed file")
+	}
+}
+
+func TestRotatedFileRotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	rf, err := OpenRotatedFile(path, 4, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"aaa", "bbb", "ccc"} {
+		if err = rf.WriteLine([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rf.Close()
+	cur, _ := os.ReadFile(path)
+	old, _ := os.ReadFile(path + ".1")
+	if string(cur) != "ccc\n" || string(old) != "bbb\n" {
+		t.Fatalf("current %q, backup %q", cur, old)
+	}
+	if _, err = os.Stat(path + ".2"); !os.IsNotExist(err) {
+		t.Fatal("extra backup kept")
+	}
+}
@@ -0,0 +1,471 @@
+package golang
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StreetType - тип улицы: каноническое сокращение и варианты написания (в нижнем регистре, без точки)
+type StreetType struct {
+	Abbr     string
+	Variants []string
+}
+
+// StreetTypes - типы улиц для нормализации. Сервисы могут дополнять список
+var StreetTypes = []StreetType{
+	{Abbr: "вул.", Variants: []string{"вул", "вулиця", "ул", "улица", "вл", "street", "st"}},
+	{Abbr: "просп.", Variants: []string{"просп", "проспект", "пр-т", "пр", "пр-кт", "prospekt", "avenue", "ave"}},
+	{Abbr: "пров.", Variants: []string{"пров", "провулок", "пер", "переулок", "пров-к"}},
+	{Abbr: "бульв.", Variants: []string{"бульв", "бульвар", "б-р", "бул", "blvd"}},
+	{Abbr: "пл.", Variants: []string{"пл", "площа", "площадь", "square", "sq"}},
+	{Abbr: "наб.", Variants: []string{"наб", "набережна", "набережная"}},
+	{Abbr: "узв.", Variants: []string{"узв", "узвіз", "спуск"}},
+	{Abbr: "шосе", Variants: []string{"ш", "шосе", "шоссе"}},
+	{Abbr: "туп.", Variants: []string{"туп", "тупик"}},
+	{Abbr: "майдан", Variants: []string{"майдан"}},
+	{Abbr: "мкрн", Variants: []string{"мкрн", "мікрорайон", "микрорайон", "мкр", "м-н"}},
+}
+
+// Префиксы и суффиксы остальных частей адреса
+var (
+	cityPrefixes     = []string{"м", "місто", "г", "город"}
+	regionSuffixes   = []string{"область", "обл", "обл."}
+	districtSuffixes = []string{"район", "р-н", "р-н.", "р"}
+	buildPrefixes    = []string{"буд", "будинок", "д", "дом", "б"}
+	apartPrefixes    = []string{"кв", "квартира", "оф", "офіс", "apt"}
+)
+
+// Латинские буквы, похожие на кириллические, и наоборот
+var (
+	latinToCyrillic = map[rune]rune{
+		'a': 'а', 'c': 'с', 'e': 'е', 'i': 'і', 'o': 'о', 'p': 'р', 'x': 'х', 'y': 'у',
+		'A': 'А', 'B': 'В', 'C': 'С', 'E': 'Е', 'H': 'Н', 'I': 'І', 'K': 'К', 'M': 'М',
+		'O': 'О', 'P': 'Р', 'T': 'Т', 'X': 'Х',
+	}
+	cyrillicToLatin = func() map[rune]rune {
+		m := make(map[rune]rune, len(latinToCyrillic))
+		for l, c := range latinToCyrillic {
+			m[c] = l
+		}
+		return m
+	}()
+)
+
+// apostrophes - варианты апострофа, заменяются на '
+const apostrophes = "’ʼ`‘´"
+
+// FixHomoglyphs - replace lookalike letters of other script in each word by letters of the word's main script.
+// Return fixed string and true if replacements were made
+func FixHomoglyphs(s string) (string, bool) {
+	var b strings.Builder
+	b.Grow(len(s))
+	changed := false
+	// строка собирается заново по токенам, каждое слово исправляется на своем месте
+	word := -1 // начало текущего слова
+	flush := func(end int) {
+		if word < 0 {
+			return
+		}
+		w := fixWord(s[word:end])
+		changed = changed || w != s[word:end]
+		b.WriteString(w)
+		word = -1
+	}
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if unicode.IsLetter(r) || r == '\'' {
+			if word < 0 {
+				word = i
+			}
+		} else {
+			flush(i)
+			b.WriteString(s[i : i+size]) // не валидные байты переносятся как есть
+		}
+		i += size
+	}
+	flush(len(s))
+	return b.String(), changed
+}
+
+// fixWord - letters of other script in the mixed word are replaced by letters of the main script
+func fixWord(w string) string {
+	cyr, lat := 0, 0
+	for _, r := range w {
+		if unicode.Is(unicode.Cyrillic, r) {
+			cyr++
+		} else if unicode.Is(unicode.Latin, r) {
+			lat++
+		}
+	}
+	if cyr == 0 || lat == 0 {
+		return w
+	}
+	table := latinToCyrillic
+	if lat > cyr {
+		table = cyrillicToLatin
+	}
+	return strings.Map(func(r rune) rune {
+		if rr, ok := table[r]; ok {
+			return rr
+		}
+		return r
+	}, w)
+}
+
+// cleanSpaces - trim, collapse spaces and unify apostrophes
+func cleanSpaces(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(apostrophes, r) {
+			return '\''
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// titleWord - first letter of the word and of each part after '-' or '.' ("т.шевченка" -> "Т.Шевченка") in upper case, others in lower case
+func titleWord(w string) string {
+	var b strings.Builder
+	b.Grow(len(w))
+	upper := true
+	for _, r := range strings.ToLower(w) {
+		if upper && unicode.IsLetter(r) {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		if r == '-' || r == '.' {
+			upper = true
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// titleName - title each word, words that start with digit (1-ша, 12а) are in lower case
+func titleName(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		if first := []rune(w)[0]; unicode.IsLetter(first) {
+			words[i] = titleWord(w)
+		} else {
+			words[i] = strings.ToLower(w)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func normToken(w string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.Trim(w, ",")), ".")
+}
+
+func inList(w string, list []string) bool {
+	for _, l := range list {
+		if w == strings.TrimSuffix(l, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitFirst - first word (prefix with a dot like "вул.Шевченка" is split too) and the rest
+func splitFirst(s string) (string, string) {
+	if i := strings.IndexAny(s, " ."); i >= 0 {
+		if s[i] == '.' {
+			return s[:i+1], strings.TrimSpace(s[i+1:])
+		}
+		return s[:i], strings.TrimSpace(s[i+1:])
+	}
+	return s, ""
+}
+
+func splitLast(s string) (string, string) {
+	if i := strings.LastIndexByte(s, ' '); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return "", s
+}
+
+// stripPrefix - remove first word of s if it is in list
+func stripPrefix(s string, list []string) (string, bool) {
+	first, rest := splitFirst(s)
+	if len(rest) != 0 && inList(normToken(first), list) {
+		return rest, true
+	}
+	return s, false
+}
+
+// stripSuffix - remove last word of s if it is in list
+func stripSuffix(s string, list []string) (string, bool) {
+	rest, last := splitLast(s)
+	if len(rest) != 0 && inList(normToken(last), list) {
+		return rest, true
+	}
+	return s, false
+}
+
+// ambiguousStreetVariants - короткие варианты, которые с заглавной буквы обычно инициал имени ("Ш. Руставелі"),
+// а не тип улицы. Типом улицы считаются только в нижнем регистре ("ш. Київське", "пр. Перемоги")
+var ambiguousStreetVariants = []string{"ш", "пр", "б"}
+
+func streetType(w string) (StreetType, bool) {
+	if first, _ := utf8.DecodeRuneInString(w); unicode.IsUpper(first) && inList(normToken(w), ambiguousStreetVariants) {
+		return StreetType{}, false
+	}
+	w = normToken(w)
+	for _, st := range StreetTypes {
+		if w == strings.TrimSuffix(st.Abbr, ".") || inList(w, st.Variants) {
+			return st, true
+		}
+	}
+	return StreetType{}, false
+}
+
+// NormalizeStreet - canonical street: "вулиця шевченка" -> "вул. Шевченка", "Лесі Українки пр-т" -> "просп. Лесі Українки"
+func NormalizeStreet(street string) string {
+	street = cleanSpaces(street)
+	if len(street) == 0 {
+		return street
+	}
+	street, _ = FixHomoglyphs(street)
+	if first, rest := splitFirst(street); len(rest) != 0 {
+		if st, ok := streetType(first); ok {
+			return st.Abbr + " " + titleName(rest)
+		}
+	}
+	if rest, last := splitLast(street); len(rest) != 0 {
+		if st, ok := streetType(last); ok {
+			return st.Abbr + " " + titleName(rest)
+		}
+	}
+	return titleName(street)
+}
+
+// normalizeNumber - build or apartment number: prefix is removed, letters in upper case, "12 а" -> "12А"
+func normalizeNumber(s string, prefixes []string) string {
+	s = cleanSpaces(s)
+	s, _ = stripPrefix(s, prefixes)
+	s, _ = FixHomoglyphs(s)
+	if f := strings.Fields(s); len(f) == 2 && len([]rune(f[1])) == 1 && unicode.IsLetter([]rune(f[1])[0]) {
+		s = f[0] + f[1]
+	}
+	return strings.ToUpper(s)
+}
+
+// normalizeName - clean, fix homoglyphs and title the name
+func normalizeName(s string) string {
+	s = cleanSpaces(s)
+	s, _ = FixHomoglyphs(s)
+	return titleName(s)
+}
+
+// AddressPart - часть полного адреса. Пустые части пропускаются
+type AddressPart func(a Address) string
+
+// Части адреса для построения FullName
+var (
+	PartStreet AddressPart = func(a Address) string { return a.Street }
+	PartBuild  AddressPart = func(a Address) string { return a.Build }
+	// PartStreetBuild - улица и дом через пробел (международный формат)
+	PartStreetBuild AddressPart = func(a Address) string { return strings.TrimSpace(a.Street + " " + a.Build) }
+	PartApartment   AddressPart = func(a Address) string {
+		if len(a.Apartment) == 0 {
+			return ""
+		}
+		return "кв. " + a.Apartment
+	}
+	PartMicroDistrict AddressPart = func(a Address) string { return a.MicroDistrict }
+	PartDistrict      AddressPart = func(a Address) string {
+		if len(a.District) == 0 {
+			return ""
+		}
+		return a.District + " р-н"
+	}
+	PartCity AddressPart = func(a Address) string {
+		if len(a.City) == 0 {
+			return ""
+		}
+		return "м. " + a.City
+	}
+	// PartCityPostCode - город и индекс (международный формат)
+	PartCityPostCode AddressPart = func(a Address) string { return strings.TrimSpace(a.City + " " + a.PostCode) }
+	PartRegion       AddressPart = func(a Address) string {
+		if len(a.Region) == 0 {
+			return ""
+		}
+		return a.Region + " обл."
+	}
+	PartPostCode AddressPart = func(a Address) string { return a.PostCode }
+	PartCountry  AddressPart = func(a Address) string { return a.Country }
+)
+
+// AddressLayout - порядок частей в полном адресе
+type AddressLayout []AddressPart
+
+// Порядки полного адреса
+var (
+	// UkrainianLayout - порядок поштової адреси України: вулиця, будинок, квартира, населений пункт, район, область, індекс, країна
+	UkrainianLayout = AddressLayout{PartStreet, PartBuild, PartApartment, PartMicroDistrict, PartCity, PartDistrict, PartRegion, PartPostCode, PartCountry}
+	// InternationalLayout - "вул. Шевченка 1, кв. 5, Київ 01001, Україна"
+	InternationalLayout = AddressLayout{PartStreetBuild, PartApartment, PartCityPostCode, PartCountry}
+)
+
+// FullName - build full name of the address by layout
+func (l AddressLayout) FullName(a Address) string {
+	parts := make([]string, 0, len(l))
+	for _, p := range l {
+		if s := p(a); len(s) != 0 {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// AddressNormalizer - приводит компоненты адреса к каноническому виду и строит FullName
+type AddressNormalizer struct {
+	Layout AddressLayout // nil - FullName не изменяется
+}
+
+// DefaultAddressNormalizer - normalizer with Ukrainian postal layout
+var DefaultAddressNormalizer = AddressNormalizer{Layout: UkrainianLayout}
+
+// Normalize - canonical form of the address components. Region and district are stored without
+// "обл." and "р-н", city - without "м." (layout adds them). Return true if homoglyphs were found
+func (n AddressNormalizer) Normalize(a *Address) (homoglyphs bool) {
+	for _, s := range []*string{&a.Country, &a.Region, &a.City, &a.District, &a.MicroDistrict, &a.Street, &a.Build, &a.Apartment} {
+		if _, ok := FixHomoglyphs(*s); ok {
+			homoglyphs = true
+		}
+	}
+	a.Country = normalizeName(a.Country)
+	a.Region, _ = stripSuffix(normalizeName(a.Region), regionSuffixes)
+	a.City, _ = stripPrefix(cleanSpaces(a.City), cityPrefixes)
+	a.City = normalizeName(a.City)
+	a.District, _ = stripSuffix(normalizeName(a.District), districtSuffixes)
+	a.MicroDistrict = cleanSpaces(a.MicroDistrict)
+	if len(a.MicroDistrict) != 0 {
+		a.MicroDistrict = NormalizeStreet(a.MicroDistrict)
+	}
+	a.Street = NormalizeStreet(a.Street)
+	a.Build = normalizeNumber(a.Build, buildPrefixes)
+	a.Apartment = normalizeNumber(a.Apartment, apartPrefixes)
+	a.PostCode = strings.ReplaceAll(cleanSpaces(a.PostCode), " ", "")
+	if n.Layout != nil {
+		a.FullName = n.Layout.FullName(*a)
+	}
+	return homoglyphs
+}
+
+// NormalizeAddress - normalize address by DefaultAddressNormalizer
+func NormalizeAddress(a *Address) bool {
+	return DefaultAddressNormalizer.Normalize(a)
+}
+
+// levenshtein - edit distance between strings in runes
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// StringSimilarity - 1 - levenshtein distance / max length (case insensitive)
+func StringSimilarity(a, b string) float64 {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	l := len([]rune(a))
+	if lb := len([]rune(b)); lb > l {
+		l = lb
+	}
+	if l == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(l)
+}
+
+// nameFolding - буквы, которые по-разному пишутся в украинском и русском вариантах названий
+var nameFolding = strings.NewReplacer("ї", "и", "і", "и", "ы", "и", "й", "и", "є", "е", "э", "е", "ё", "е", "ґ", "г", "'", "", "ь", "", "ъ", "")
+
+// NameSimilarity - similarity of the names ignoring differences of Ukrainian and Russian spelling (Київ - Киев)
+func NameSimilarity(a, b string) float64 {
+	return StringSimilarity(nameFolding.Replace(strings.ToLower(a)), nameFolding.Replace(strings.ToLower(b)))
+}
+
+// streetName - street without type
+func streetName(street string) string {
+	if first, rest := splitFirst(street); len(rest) != 0 {
+		if _, ok := streetType(first); ok {
+			return rest
+		}
+	}
+	return street
+}
+
+// EarthRadius - средний радиус Земли в метрах (пакет geo использует эту же константу)
+const EarthRadius = 6371008.8
+
+// AddressComparer - поиск дубликатов адресов
+type AddressComparer struct {
+	Threshold   float64 // минимальная схожесть дубликатов (0..1)
+	MaxDistance float64 // адреса ближе MaxDistance метров считаются совпадающими по координатам
+}
+
+// DefaultAddressComparer - street names may differ by typos, building and apartment must be equal
+var DefaultAddressComparer = AddressComparer{Threshold: 0.85, MaxDistance: 30}
+
+// Similarity - similarity of the normalized addresses from 0 to 1.
+// Different building or apartment numbers or far coordinates give 0
+func (c AddressComparer) Similarity(a, b Address) float64 {
+	n := AddressNormalizer{}
+	n.Normalize(&a)
+	n.Normalize(&b)
+	if a.Build != b.Build || a.Apartment != b.Apartment {
+		return 0
+	}
+	if len(a.PostCode) != 0 && len(b.PostCode) != 0 && a.PostCode != b.PostCode {
+		return 0
+	}
+	if (a.Lat != 0 || a.Lng != 0) && (b.Lat != 0 || b.Lng != 0) && c.MaxDistance > 0 {
+		// равнопрямоугольная проекция достаточно точна на расстояниях в десятки метров
+		x := (b.Lng - a.Lng) * math.Pi / 180 * math.Cos((a.Lat+b.Lat)/2*math.Pi/180)
+		y := (b.Lat - a.Lat) * math.Pi / 180
+		if EarthRadius*math.Hypot(x, y) > c.MaxDistance {
+			return 0
+		}
+	}
+	street := NameSimilarity(streetName(a.Street), streetName(b.Street))
+	city := 1.0
+	if len(a.City) != 0 && len(b.City) != 0 {
+		city = NameSimilarity(a.City, b.City)
+	}
+	return 0.7*street + 0.3*city
+}
+
+// IsDuplicate - addresses are the same place
+func (c AddressComparer) IsDuplicate(a, b Address) bool {
+	return c.Similarity(a, b) >= c.Threshold
+}
@@ -0,0 +1,158 @@
+package golang
+
+import "testing"
+
+func TestFixHomoglyphs(t *testing.T) {
+	cases := []struct {
+		in, want string
+		changed  bool
+	}{
+		{"Шевченка", "Шевченка", false},
+		{"Шeвчeнкa", "Шевченка", true}, // латинские e и a
+		{"Kyiv", "Kyiv", false},
+		{"Kyїv", "Kyїv", false}, // ї не имеет латинского двойника
+		{"Kиїв, Kиїв", "Київ, Київ", true},
+		// одинаковое слово как часть другого заменяется только на своем месте
+		{"ТOВ ТOВар", "ТОВ ТОВар", true},
+		{"Нoвa, Нoвa", "Нова, Нова", true},
+		{"Сoffee Сo", "Coffee Со", true},
+		{"вул. Гoлoсіївська,  12", "вул. Голосіївська,  12", true},
+		{"O'Нeil \xff", "O'Heil \xff", true},
+	}
+	for _, c := range cases {
+		got, changed := FixHomoglyphs(c.in)
+		if got != c.want || changed != c.changed {
+			t.Errorf("%q: got %q %v, want %q %v", c.in, got, changed, c.want, c.changed)
+		}
+	}
+}
+
+func TestNormalizeStreet(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"вулиця шевченка", "вул. Шевченка"},
+		{"Лесі Українки пр-т", "просп. Лесі Українки"},
+		{"Ш. Руставелі", "Ш. Руставелі"},
+		{"вул. Ш. Руставелі", "вул. Ш. Руставелі"},
+		{"ш. київське", "шосе Київське"},
+		{"пр. перемоги", "просп. Перемоги"},
+		{"Пр. Перемоги", "Пр. Перемоги"},
+		{"Харківське ш.", "шосе Харківське"},
+		{"б-р Дружби Народів", "бульв. Дружби Народів"},
+		{"вул. т.шевченка", "вул. Т.Шевченка"},
+		{"ВУЛ. ІВАНА ФРАНКА", "вул. Івана Франка"},
+		{"вул. 1-ша травнева", "вул. 1-ша Травнева"},
+		{"набережна дніпровська", "наб. Дніпровська"},
+	}
+	for _, c := range cases {
+		if got := NormalizeStreet(c.in); got != c.want {
+			t.Errorf("%q: got %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestAddressComparerDistance(t *testing.T) {
+	a := Address{City: "Київ", Street: "вул. Хрещатик", Build: "1", Lat: 50.4501, Lng: 30.5234}
+	b := a
+	b.Lat += 0.0001 // около 11 м
+	if !DefaultAddressComparer.IsDuplicate(a, b) {
+		t.Fatal("near address is not duplicate")
+	}
+	b.Lat += 0.001 // более 100 м
+	if DefaultAddressComparer.IsDuplicate(a, b) {
+		t.Fatal("far address is duplicate")
+	}
+}
+
+func TestAddressNormalize(t *testing.T) {
+	cases := []struct {
+		name       string
+		in, want   Address
+		homoglyphs bool
+	}{
+		{
+			name: "prefixes and suffixes",
+			in: Address{Country: "україна", Region: "київська обл.", City: "м. бровари", District: "броварський р-н",
+				Street: "вулиця незалежності", Build: "буд. 12 а", Apartment: "кв. 5", PostCode: "07 400"},
+			want: Address{Country: "Україна", Region: "Київська", City: "Бровари", District: "Броварський",
+				Street: "вул. Незалежності", Build: "12А", Apartment: "5", PostCode: "07400"},
+		},
+		{
+			name: "full words without dots",
+			in:   Address{Region: "одеська область", City: "місто одеса", District: "приморський район", Build: "д.7", Apartment: "офіс 3б"},
+			want: Address{Region: "Одеська", City: "Одеса", District: "Приморський", Build: "7", Apartment: "3Б"},
+		},
+		{
+			name: "prefix without space",
+			in:   Address{City: "г.Киев", Build: "будинок 1/2"},
+			want: Address{City: "Киев", Build: "1/2"},
+		},
+		{
+			name: "name equal to prefix is kept",
+			in:   Address{City: "м", Region: "обл"},
+			want: Address{City: "М", Region: "Обл"},
+		},
+		{
+			name:       "homoglyphs",
+			in:         Address{City: "Kиїв", Street: "вул. Хрещaтик"},
+			want:       Address{City: "Київ", Street: "вул. Хрещатик"},
+			homoglyphs: true,
+		},
+	}
+	for _, c := range cases {
+		got := c.in
+		if h := (AddressNormalizer{}).Normalize(&got); h != c.homoglyphs || got != c.want {
+			t.Errorf("%s: got %+v %v, want %+v %v", c.name, got, h, c.want, c.homoglyphs)
+		}
+	}
+}
+
+func TestAddressLayouts(t *testing.T) {
+	a := Address{Country: "Україна", Region: "Київська", City: "Бровари", District: "Броварський",
+		Street: "вул. Незалежності", Build: "12А", Apartment: "5", PostCode: "07400"}
+	cases := []struct {
+		name   string
+		layout AddressLayout
+		a      Address
+		want   string
+	}{
+		{"ukrainian", UkrainianLayout, a, "вул. Незалежності, 12А, кв. 5, м. Бровари, Броварський р-н, Київська обл., 07400, Україна"},
+		{"international", InternationalLayout, a, "вул. Незалежності 12А, кв. 5, Бровари 07400, Україна"},
+		{"ukrainian without empty parts", UkrainianLayout, Address{City: "Київ", Street: "вул. Хрещатик", Build: "1"}, "вул. Хрещатик, 1, м. Київ"},
+		{"international without street", InternationalLayout, Address{City: "Київ", Country: "Україна"}, "Київ, Україна"},
+		{"empty", UkrainianLayout, Address{}, ""},
+	}
+	for _, c := range cases {
+		if got := c.layout.FullName(c.a); got != c.want {
+			t.Errorf("%s: %q", c.name, got)
+		}
+	}
+	raw := Address{City: "м. київ", Street: "хрещатик вул", Build: "1"}
+	if NormalizeAddress(&raw); raw.FullName != "вул. Хрещатик, 1, м. Київ" {
+		t.Errorf("default normalizer full name %q", raw.FullName)
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		min, max float64
+	}{
+		{"Київ", "київ", 1, 1},
+		{"Харків", "Харьков", 0.8, 0.9}, // украинское и русское написание
+		{"Запоріжжя", "Запорожье", 0.6, 0.7},
+		{"Київ", "Киев", 0.75, 1},
+		{"Одеса", "Одесса", 0.8, 0.9},
+		{"Львів", "Харків", 0, 0.5},
+		{"", "", 1, 1},
+		{"Суми", "", 0, 0},
+	}
+	for _, c := range cases {
+		got := NameSimilarity(c.a, c.b)
+		if got < c.min || got > c.max {
+			t.Errorf("%s - %s: %.3f, want %.2f..%.2f", c.a, c.b, got, c.min, c.max)
+		}
+		if back := NameSimilarity(c.b, c.a); back != got {
+			t.Errorf("%s - %s: not symmetric %.3f %.3f", c.a, c.b, got, back)
+		}
+	}
+}
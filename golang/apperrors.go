@@ -1,22 +1,89 @@
 package golang
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // EgeonError - implement error and json interfaces for errors in system. TODO optimize it later
+// Ошибки сравнимы (==), подробности по полям хранятся по указателю
 type EgeonError struct {
-	Code        uint32 `json:"Code"`
-	Description string `json:"Description"`
+	Code        uint32       `json:"Code"`
+	Description string       `json:"Description"`
+	Fields      *FieldErrors `json:"Fields,omitempty"` // Ошибки валидации отдельных полей (для ValidateError)
+}
+
+// FieldError - ошибка валидации поля. Field - путь к полю по json тегам (например address.lat)
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldErrors - список ошибок полей
+type FieldErrors []FieldError
+
+// WithFields - copy of the error with details of the fields
+func (e EgeonError) WithFields(fields ...FieldError) EgeonError {
+	if len(fields) == 0 {
+		e.Fields = nil
+		return e
+	}
+	list := FieldErrors(fields)
+	e.Fields = &list
+	return e
+}
+
+// FieldList - details of the fields, nil if there are not
+func (e EgeonError) FieldList() []FieldError {
+	if e.Fields == nil {
+		return nil
+	}
+	return *e.Fields
+}
+
+// Is - EgeonError matches the error with the same code and description (details of the fields are ignored)
+// and the error from Errors with the same text, so errors.Is(err, Errors["badEmail"]) works for both forms
+func (e EgeonError) Is(target error) bool {
+	switch t := target.(type) {
+	case EgeonError:
+		return t.Code == e.Code && t.Description == e.Description
+	case *EgeonError:
+		return t != nil && t.Code == e.Code && t.Description == e.Description
+	case nil:
+		return false
+	}
+	return target.Error() == e.Description
 }
 
 func (e EgeonError) Error() string {
-	return fmt.Sprintf("Error Code %d, Description: %s", e.Code, e.Description)
+	fields := e.FieldList()
+	if len(fields) == 0 {
+		return fmt.Sprintf("Error Code %d, Description: %s", e.Code, e.Description)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Error Code %d, Description: %s", e.Code, e.Description)
+	for _, f := range fields {
+		fmt.Fprintf(&b, "; %s: %s", f.Field, f.Message)
+	}
+	return b.String()
 }
 
 func (e EgeonError) MarshalJSON() ([]byte, error) {
-	return []byte(fmt.Sprintf("{\"Code\":%d, \"Description\": %q}", e.Code, e.Description)), nil
+	if len(e.FieldList()) == 0 {
+		return []byte(fmt.Sprintf("{\"Code\":%d, \"Description\": %q}", e.Code, e.Description)), nil
+	}
+	fields, err := json.Marshal(e.FieldList())
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("{\"Code\":%d, \"Description\": %q, \"Fields\": %s}", e.Code, e.Description, fields)), nil
+}
+
+// NewValidateError - error with code ValidateError and details of the fields
+func NewValidateError(fields ...FieldError) EgeonError {
+	return EgeonError{Code: ValidateError, Description: Errors["incorrectInput"].Error()}.WithFields(fields...)
 }
 
 const (
@@ -73,4 +140,14 @@ func init() {
 	Errors["badEmail"] = GetErr("Перевірте правильність ведення электроної пошти")
 	Errors["badReqID"] = GetErr("Не відомий request ID")
 	Errors["badKey"] = GetErr("Не вірний пароль або ключ авторизації")
+	Errors["badSession"] = GetErr("Сесію не знайдено або час Вашої сесії закінчився")
+	Errors["badToken"] = GetErr("Посилання не дійсне або вже було використане. Спробуйте отримати нове")
+	Errors["badPhone"] = GetErr("Перевірте правильність ведення номеру телефону")
+	Errors["badCompanyCode"] = GetErr("Не вірний код ЄДРПОУ")
+	Errors["tooManyRequests"] = GetErr("Забагато запитів. Спробуйте пізніше")
+	Errors["badPatch"] = GetErr("Не коректний документ змін (patch)")
+	Errors["patchTestFailed"] = GetErr("Дані були змінені. Оновіть сторінку та спробуйте ще раз")
+	Errors["versionConflict"] = GetErr("Дані були змінені іншим користувачем. Оновіть сторінку та спробуйте ще раз")
+	Errors["internal"] = GetErr("Внутрішня помилка сервісу. Спробуйте пізніше")
+	Errors["versionRequired"] = GetErr("Запит на зміну має містити версію даних (заголовок If-Match)")
 }
@@ -0,0 +1,57 @@
+package golang
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestEgeonErrorComparable(t *testing.T) {
+	err := NewValidateError(FieldError{Field: "email", Message: "bad"})
+	var target error = EgeonError{Code: ValidateError, Description: Errors["incorrectInput"].Error()}
+	if err == target {
+		t.Fatal("error with fields equals error without them")
+	}
+	if !errors.Is(fmt.Errorf("wrap: %w", err), target) {
+		t.Fatal("errors.Is does not match by code and description")
+	}
+	if !errors.Is(err, Errors["incorrectInput"]) || errors.Is(err, Errors["badEmail"]) {
+		t.Fatal("errors.Is does not match Errors by text")
+	}
+	if errors.Is(err, EgeonError{Code: InternalError, Description: err.Description}) {
+		t.Fatal("other code matched")
+	}
+	m := map[error]int{err: 1}
+	if m[err] != 1 {
+		t.Fatal("error is not usable as a map key")
+	}
+}
+
+func TestEgeonErrorJSON(t *testing.T) {
+	cases := []struct {
+		err  EgeonError
+		want string
+	}{
+		{EgeonError{Code: NotAuthError, Description: "a"}, `{"Code":6,"Description":"a"}`},
+		{EgeonError{Code: NotAuthError, Description: "a"}.WithFields(), `{"Code":6,"Description":"a"}`},
+		{EgeonError{Code: ValidateError, Description: "b"}.WithFields(FieldError{Field: "phone", Message: "c"}),
+			`{"Code":21,"Description":"b","Fields":[{"field":"phone","message":"c"}]}`},
+	}
+	for _, c := range cases {
+		data, err := json.Marshal(c.err)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got, want interface{}
+		json.Unmarshal(data, &got)
+		json.Unmarshal([]byte(c.want), &want)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("got %s, want %s", data, c.want)
+		}
+		var back EgeonError
+		if err = json.Unmarshal(data, &back); err != nil || !errors.Is(back, c.err) || len(back.FieldList()) != len(c.err.FieldList()) {
+			t.Errorf("decoded %+v, %v", back, err)
+		}
+	}
+}
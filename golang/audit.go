@@ -0,0 +1,387 @@
+package golang
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AuditSecretFields - значения этих полей (последний элемент пути) не сохраняются в истории, только факт изменения
+var AuditSecretFields = []string{"passHash", "salt", "sessionKey", "token", "avatarB64"}
+
+// AuditEntityType - entity type of the DTO for audit records: name of the type in lower camel case (User - user, UserProfile - userProfile)
+func AuditEntityType(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	name := t.Name()
+	r, n := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[n:]
+}
+
+func auditSecret(path string) bool {
+	field := path[strings.LastIndexByte(path, '.')+1:]
+	for _, f := range AuditSecretFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldChanges - changed leaves of the documents with values
+func fieldChanges(before, after interface{}, prefix string, res *[]FieldChange) {
+	bm, ok1 := before.(map[string]interface{})
+	am, ok2 := after.(map[string]interface{})
+	// отсутствующий объект сравнивается по полям с пустым
+	if ok1 && after == nil {
+		am, ok2 = map[string]interface{}{}, true
+	} else if ok2 && before == nil {
+		bm, ok1 = map[string]interface{}{}, true
+	}
+	if !ok1 || !ok2 {
+		if jsonEqual(before, after) || jsonZero(before) && jsonZero(after) {
+			return
+		}
+		c := FieldChange{Field: prefix, Before: before, After: after}
+		if auditSecret(prefix) {
+			c.Before, c.After = nil, nil
+			if before != nil {
+				c.Before = RedactedMark
+			}
+			if after != nil {
+				c.After = RedactedMark
+			}
+		}
+		*res = append(*res, c)
+		return
+	}
+	for _, k := range sortedKeys(bm) {
+		fieldChanges(bm[k], am[k], joinPath(prefix, k), res)
+	}
+	for _, k := range sortedKeys(am) {
+		if _, ok := bm[k]; !ok {
+			fieldChanges(nil, am[k], joinPath(prefix, k), res)
+		}
+	}
+}
+
+// jsonZero - value of the document is empty (omitempty fields are missing, so they are equal to nil)
+func jsonZero(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return len(val) == 0 || val == "0001-01-01T00:00:00Z"
+	case bool:
+		return !val
+	case json.Number:
+		f, err := val.Float64()
+		return err == nil && f == 0
+	case map[string]interface{}:
+		return len(val) == 0
+	case []interface{}:
+		return len(val) == 0
+	}
+	return false
+}
+
+// FieldDiff - field level difference of two versions of the DTO. Nil before (after) means created (deleted) entity.
+// Values of AuditSecretFields are replaced by RedactedMark
+func FieldDiff(before, after interface{}) ([]FieldChange, error) {
+	doc := func(v interface{}) (interface{}, error) {
+		if v == nil || reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil() {
+			return map[string]interface{}{}, nil
+		}
+		return toDocument(v)
+	}
+	b, err := doc(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := doc(after)
+	if err != nil {
+		return nil, err
+	}
+	var res []FieldChange
+	fieldChanges(b, a, "", &res)
+	return res, nil
+}
+
+// NewAuditRecord - audit record of the entity change. Actor and request ID are taken from context
+func NewAuditRecord(ctx context.Context, entityType string, entityID uint64, action uint32, before, after interface{}) (AuditRecord, error) {
+	changes, err := FieldDiff(before, after)
+	if err != nil {
+		return AuditRecord{}, err
+	}
+	rec := AuditRecord{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Changes:    changes,
+		AddedDate:  time.Now().UTC(),
+	}
+	if p, ok := PrincipalFromContext(ctx); ok {
+		rec.ActorID = p.ID
+	}
+	rec.RequestID, _ = ctx.Value(RequestID).(string)
+	return rec, nil
+}
+
+// AuditSink - получатель записей истории изменений (SQL таблица, redis stream, файл)
+type AuditSink interface {
+	WriteAudit(ctx context.Context, records []AuditRecord) error
+}
+
+// AuditQuery - параметры запроса истории сущности
+type AuditQuery struct {
+	EntityType string
+	EntityID   uint64
+	Since      time.Time // нулевое значение - без ограничения
+	Until      time.Time // нулевое значение - без ограничения
+	Limit      int       // 0 - DefaultAuditLimit
+	Offset     int
+}
+
+// DefaultAuditLimit - количество записей истории, если Limit не задан
+const DefaultAuditLimit = 50
+
+func (q AuditQuery) limit() int {
+	if q.Limit <= 0 {
+		return DefaultAuditLimit
+	}
+	return q.Limit
+}
+
+// Validate - check paging parameters of the query
+func (q AuditQuery) Validate() error {
+	var fields []FieldError
+	if q.Offset < 0 {
+		fields = append(fields, FieldError{Field: "offset", Message: "не може бути від'ємним"})
+	}
+	if q.Limit < 0 {
+		fields = append(fields, FieldError{Field: "limit", Message: "не може бути від'ємним"})
+	}
+	if len(fields) != 0 {
+		return NewValidateError(fields...)
+	}
+	return nil
+}
+
+func (q AuditQuery) match(r *AuditRecord) bool {
+	return r.EntityType == q.EntityType && r.EntityID == q.EntityID &&
+		(q.Since.IsZero() || !r.AddedDate.Before(q.Since)) &&
+		(q.Until.IsZero() || r.AddedDate.Before(q.Until))
+}
+
+// AuditStore - sink that can return history of the entity (the newest records first)
+type AuditStore interface {
+	AuditSink
+	History(ctx context.Context, q AuditQuery) ([]AuditRecord, error)
+}
+
+// Audit - write record of the entity change to the sink. Update without changes is not recorded
+func Audit(ctx context.Context, sink AuditSink, entityType string, entityID uint64, action uint32, before, after interface{}) error {
+	rec, err := NewAuditRecord(ctx, entityType, entityID, action, before, after)
+	if err != nil {
+		return err
+	}
+	if action == Updated && len(rec.Changes) == 0 {
+		return nil
+	}
+	return sink.WriteAudit(ctx, []AuditRecord{rec})
+}
+
+// SQLAuditStore - история изменений в таблице PostgreSQL (см. CreateTableSQL)
+type SQLAuditStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewSQLAuditStore - store in the table (audit_log if empty)
+func NewSQLAuditStore(db *sql.DB, table string) *SQLAuditStore {
+	if len(table) == 0 {
+		table = "audit_log"
+	}
+	return &SQLAuditStore{db: db, table: table}
+}
+
+// CreateTableSQL - DDL of the audit table and its index
+func (s *SQLAuditStore) CreateTableSQL() string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+	id BIGSERIAL PRIMARY KEY,
+	entity_type VARCHAR(64) NOT NULL,
+	entity_id BIGINT NOT NULL,
+	action INTEGER NOT NULL,
+	actor_id INTEGER,
+	request_id VARCHAR(64),
+	changes JSONB,
+	added_date TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS %[1]s_entity_idx ON %[1]s (entity_type, entity_id, added_date DESC);`, s.table)
+}
+
+func (s *SQLAuditStore) WriteAudit(ctx context.Context, records []AuditRecord) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO "+s.table+
+		" (entity_type, entity_id, action, actor_id, request_id, changes, added_date) VALUES ($1, $2, $3, $4, $5, $6, $7)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for i := range records {
+		args, err := auditInsertArgs(&records[i])
+		if err != nil {
+			return err
+		}
+		if _, err = stmt.ExecContext(ctx, args...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// auditInsertArgs - values of the insert statement. Record without actor (system change) or request ID stores NULL
+func auditInsertArgs(r *AuditRecord) ([]interface{}, error) {
+	changes, err := json.Marshal(r.Changes)
+	if err != nil {
+		return nil, err
+	}
+	actor := sql.NullInt64{Int64: int64(r.ActorID), Valid: r.ActorID != 0}
+	requestID := sql.NullString{String: r.RequestID, Valid: len(r.RequestID) != 0}
+	return []interface{}{r.EntityType, int64(r.EntityID), int64(r.Action), actor, requestID, string(changes), r.AddedDate}, nil
+}
+
+// historyQuery - select statement of the query with its arguments
+func (s *SQLAuditStore) historyQuery(q AuditQuery) (string, []interface{}) {
+	query := "SELECT id, entity_type, entity_id, action, COALESCE(actor_id, 0), COALESCE(request_id, ''), changes, added_date FROM " +
+		s.table + " WHERE entity_type = $1 AND entity_id = $2"
+	args := []interface{}{q.EntityType, int64(q.EntityID)}
+	if !q.Since.IsZero() {
+		args = append(args, q.Since)
+		query += fmt.Sprintf(" AND added_date >= $%d", len(args))
+	}
+	if !q.Until.IsZero() {
+		args = append(args, q.Until)
+		query += fmt.Sprintf(" AND added_date < $%d", len(args))
+	}
+	query += fmt.Sprintf(" ORDER BY added_date DESC, id DESC LIMIT %d OFFSET %d", q.limit(), q.Offset)
+	return query, args
+}
+
+func (s *SQLAuditStore) History(ctx context.Context, q AuditQuery) ([]AuditRecord, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	query, args := s.historyQuery(q)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var res []AuditRecord
+	for rows.Next() {
+		var r AuditRecord
+		var changes []byte
+		if err = rows.Scan(&r.ID, &r.EntityType, &r.EntityID, &r.Action, &r.ActorID, &r.RequestID, &changes, &r.AddedDate); err != nil {
+			return nil, err
+		}
+		if len(changes) != 0 {
+			if err = json.Unmarshal(changes, &r.Changes); err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, r)
+	}
+	return res, rows.Err()
+}
+
+// AuditFileStore - история изменений в JSON lines файле с ротацией по размеру.
+// History читает файл и его ротированные копии полностью, подходит для небольших объемов и тестов
+type AuditFileStore struct {
+	lines *RotatedFile
+}
+
+// NewAuditFileStore - JSON lines audit store (see NewFileSink for rotation parameters)
+func NewAuditFileStore(path string, maxSize int64, maxBackups int) (*AuditFileStore, error) {
+	f, err := OpenRotatedFile(path, maxSize, maxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditFileStore{lines: f}, nil
+}
+
+func (s *AuditFileStore) WriteAudit(ctx context.Context, records []AuditRecord) error {
+	for i := range records {
+		data, err := records[i].MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if err = s.lines.WriteLine(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AuditFileStore) History(ctx context.Context, q AuditQuery) ([]AuditRecord, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	rf := s.lines
+	rf.mt.Lock()
+	defer rf.mt.Unlock()
+	var found []AuditRecord
+	// от самой старой копии к текущему файлу
+	for i := rf.maxBackups; i >= 0; i-- {
+		path := rf.path
+		if i > 0 {
+			path = fmt.Sprintf("%s.%d", rf.path, i)
+		}
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sc := bufio.NewScanner(f)
+		sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for sc.Scan() {
+			var r AuditRecord
+			if r.UnmarshalJSON(sc.Bytes()) == nil && q.match(&r) {
+				found = append(found, r)
+			}
+		}
+		err = sc.Err()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	res := make([]AuditRecord, 0, q.limit())
+	for i := len(found) - 1 - q.Offset; i >= 0 && len(res) < q.limit(); i-- {
+		res = append(res, found[i])
+	}
+	return res, nil
+}
+
+func (s *AuditFileStore) Close() error {
+	return s.lines.Close()
+}
@@ -0,0 +1,202 @@
+package golang
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func changesByField(changes []FieldChange) map[string]string {
+	res := make(map[string]string, len(changes))
+	for _, c := range changes {
+		res[c.Field] = fmt.Sprint(c.Before, " -> ", c.After)
+	}
+	return res
+}
+
+func TestFieldDiff(t *testing.T) {
+	before := User{ID: 1, Email: "a@egeon.ua", PassHash: "old", Profile: UserProfile{Name: "Іван", City: "Київ"},
+		Company: Company{ID: 2, Name: "ТОВ"}}
+	after := before
+	after.Email = "b@egeon.ua"
+	after.PassHash = "new"
+	after.Profile.City = "Львів"
+	after.Company.Name = "ПП"
+	after.SessionKey = "key"
+	changes, err := FieldDiff(before, &after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"email":        "a@egeon.ua -> b@egeon.ua",
+		"passHash":     RedactedMark + " -> " + RedactedMark,
+		"profile.city": "Київ -> Львів",
+		"company.name": "ТОВ -> ПП",
+		"sessionKey":   "<nil> -> " + RedactedMark,
+	}
+	if got := changesByField(changes); !reflect.DeepEqual(got, want) {
+		t.Errorf("changes %v", got)
+	}
+	if changes, _ = FieldDiff(before, before); len(changes) != 0 {
+		t.Errorf("no changes expected: %v", changes)
+	}
+}
+
+func TestFieldDiffCreatedDeleted(t *testing.T) {
+	u := &User{ID: 3, Email: "a@egeon.ua", Salt: "pepper"}
+	created, err := FieldDiff(nil, u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := changesByField(created)
+	if got["id"] != "<nil> -> 3" || got["email"] != "<nil> -> a@egeon.ua" || got["salt"] != "<nil> -> "+RedactedMark {
+		t.Errorf("created %v", got)
+	}
+	if _, ok := got["phone"]; ok {
+		t.Errorf("empty field in created %v", got)
+	}
+	var none *User
+	deleted, err := FieldDiff(u, none)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = changesByField(deleted)
+	if got["id"] != "3 -> <nil>" || got["salt"] != RedactedMark+" -> <nil>" || len(got) != len(created) {
+		t.Errorf("deleted %v", got)
+	}
+}
+
+type auditRecorder struct{ records []AuditRecord }
+
+func (r *auditRecorder) WriteAudit(ctx context.Context, records []AuditRecord) error {
+	r.records = append(r.records, records...)
+	return nil
+}
+
+func TestAudit(t *testing.T) {
+	ctx := context.WithValue(context.Background(), PrincipalKey, Principal{ID: 9})
+	ctx = context.WithValue(ctx, RequestID, "01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	sink := &auditRecorder{}
+	u := User{ID: 1, Email: "a@egeon.ua"}
+	if err := Audit(ctx, sink, AuditEntityType(&u), 1, Updated, u, u); err != nil || len(sink.records) != 0 {
+		t.Fatalf("update without changes recorded: %v %v", sink.records, err)
+	}
+	changed := u
+	changed.Email = "b@egeon.ua"
+	if err := Audit(ctx, sink, AuditEntityType(&u), 1, Updated, u, changed); err != nil || len(sink.records) != 1 {
+		t.Fatalf("%v %v", sink.records, err)
+	}
+	rec := sink.records[0]
+	if rec.EntityType != "user" || rec.ActorID != 9 || rec.RequestID != "01ARZ3NDEKTSV4RRFFQ69G5FAV" || len(rec.Changes) != 1 {
+		t.Errorf("record %+v", rec)
+	}
+}
+
+func TestAuditFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	store, err := NewAuditFileStore(path, 300, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		recs := []AuditRecord{
+			{EntityType: "user", EntityID: 1, Action: Updated, AddedDate: start.Add(time.Duration(i) * time.Hour),
+				Changes: []FieldChange{{Field: "email", After: fmt.Sprintf("u%d@egeon.ua", i)}}},
+			{EntityType: "user", EntityID: 2, Action: Updated, AddedDate: start.Add(time.Duration(i) * time.Hour)},
+		}
+		if err = store.WriteAudit(ctx, recs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = os.Stat(path + ".1"); err != nil {
+		t.Fatalf("file is not rotated: %v", err)
+	}
+	hours := func(recs []AuditRecord) []int {
+		res := make([]int, len(recs))
+		for i := range recs {
+			res[i] = int(recs[i].AddedDate.Sub(start).Hours())
+		}
+		return res
+	}
+	cases := []struct {
+		q    AuditQuery
+		want []int
+	}{
+		{AuditQuery{EntityType: "user", EntityID: 1}, []int{5, 4, 3, 2, 1, 0}},
+		{AuditQuery{EntityType: "user", EntityID: 1, Limit: 2}, []int{5, 4}},
+		{AuditQuery{EntityType: "user", EntityID: 1, Limit: 2, Offset: 4}, []int{1, 0}},
+		{AuditQuery{EntityType: "user", EntityID: 1, Offset: 10}, []int{}},
+		{AuditQuery{EntityType: "user", EntityID: 1, Since: start.Add(2 * time.Hour), Until: start.Add(4 * time.Hour)}, []int{3, 2}},
+		{AuditQuery{EntityType: "user", EntityID: 3}, []int{}},
+	}
+	for _, c := range cases {
+		res, err := store.History(ctx, c.q)
+		if err != nil || !reflect.DeepEqual(hours(res), c.want) {
+			t.Errorf("%+v: %v, %v", c.q, hours(res), err)
+		}
+	}
+	res, _ := store.History(ctx, AuditQuery{EntityType: "user", EntityID: 1, Limit: 1})
+	if len(res) != 1 || len(res[0].Changes) != 1 || res[0].Changes[0].After != "u5@egeon.ua" {
+		t.Errorf("record is not restored: %+v", res)
+	}
+	var e EgeonError
+	if _, err = store.History(ctx, AuditQuery{EntityType: "user", EntityID: 1, Offset: -1}); !errors.As(err, &e) || e.Code != ValidateError {
+		t.Errorf("negative offset: %v", err)
+	}
+}
+
+func TestSQLAuditStoreQuery(t *testing.T) {
+	s := NewSQLAuditStore(nil, "")
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(time.Hour)
+	const head = "SELECT id, entity_type, entity_id, action, COALESCE(actor_id, 0), COALESCE(request_id, ''), changes, added_date FROM audit_log WHERE entity_type = $1 AND entity_id = $2"
+	cases := []struct {
+		q     AuditQuery
+		query string
+		args  []interface{}
+	}{
+		{AuditQuery{EntityType: "user", EntityID: 1}, head + " ORDER BY added_date DESC, id DESC LIMIT 50 OFFSET 0", []interface{}{"user", int64(1)}},
+		{AuditQuery{EntityType: "user", EntityID: 1, Since: since, Limit: 10, Offset: 20},
+			head + " AND added_date >= $3 ORDER BY added_date DESC, id DESC LIMIT 10 OFFSET 20", []interface{}{"user", int64(1), since}},
+		{AuditQuery{EntityType: "company", EntityID: 2, Until: until},
+			head + " AND added_date < $3 ORDER BY added_date DESC, id DESC LIMIT 50 OFFSET 0", []interface{}{"company", int64(2), until}},
+		{AuditQuery{EntityType: "user", EntityID: 1, Since: since, Until: until},
+			head + " AND added_date >= $3 AND added_date < $4 ORDER BY added_date DESC, id DESC LIMIT 50 OFFSET 0", []interface{}{"user", int64(1), since, until}},
+	}
+	for _, c := range cases {
+		query, args := s.historyQuery(c.q)
+		if query != c.query || !reflect.DeepEqual(args, c.args) {
+			t.Errorf("%+v:\n%s\n%v", c.q, query, args)
+		}
+	}
+	if _, err := s.History(context.Background(), AuditQuery{Offset: -1}); err == nil {
+		t.Error("negative offset accepted")
+	}
+}
+
+func TestSQLAuditInsertArgs(t *testing.T) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	system := AuditRecord{EntityType: "user", EntityID: 1, Action: Deleted, AddedDate: date}
+	args, err := auditInsertArgs(&system)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{"user", int64(1), int64(Deleted), sql.NullInt64{}, sql.NullString{}, "null", date}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("record without actor: %v", args)
+	}
+	user := AuditRecord{EntityType: "user", EntityID: 1, ActorID: 5, RequestID: "id", Changes: []FieldChange{{Field: "email", After: "a"}}}
+	args, _ = auditInsertArgs(&user)
+	if args[3] != (sql.NullInt64{Int64: 5, Valid: true}) || args[4] != (sql.NullString{String: "id", Valid: true}) || args[5] != `[{"field":"email","after":"a"}]` {
+		t.Errorf("record of user: %v", args)
+	}
+}
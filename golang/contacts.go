@@ -0,0 +1,260 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+const (
+	maxEmailLen      = 254
+	maxEmailLocalLen = 64
+)
+
+// emailLocalChars - допустимые символы локальной части адреса кроме букв и цифр (RFC 5322 atext)
+const emailLocalChars = "!#$%&'*+/=?^_`{|}~-"
+
+func validEmailLocal(local string) bool {
+	if len(local) == 0 || len(local) > maxEmailLocalLen || local[0] == '.' || local[len(local)-1] == '.' || strings.Contains(local, "..") {
+		return false
+	}
+	for i := 0; i < len(local); i++ {
+		c := local[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || strings.IndexByte(emailLocalChars, c) >= 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func validDomain(domain string) bool {
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, l := range labels {
+		if len(l) == 0 || len(l) > 63 || l[0] == '-' || l[len(l)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(l); i++ {
+			if c := l[i]; !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	_, err := strconv.Atoi(labels[len(labels)-1])
+	return err != nil
+}
+
+// NormalizeEmail - check syntax of the email and return it in lower case.
+// International (IDN) domain is converted to punycode, so the same address always has one form
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 || at == len(email)-1 {
+		return "", Errors["badEmail"]
+	}
+	local := email[:at]
+	if !validEmailLocal(local) {
+		return "", Errors["badEmail"]
+	}
+	domain, err := idna.Lookup.ToASCII(strings.TrimSuffix(email[at+1:], "."))
+	if err != nil {
+		return "", Errors["badEmail"]
+	}
+	domain = strings.ToLower(domain)
+	if !validDomain(domain) {
+		return "", Errors["badEmail"]
+	}
+	res := strings.ToLower(local) + "@" + domain
+	if len(res) > maxEmailLen {
+		return "", Errors["badEmail"]
+	}
+	return res, nil
+}
+
+// MXResolver - поиск MX записей и адресов домена. *net.Resolver реализует этот интерфейс
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// EmailValidator - проверка email с опциональной проверкой MX записей домена
+type EmailValidator struct {
+	Resolver MXResolver // nil - MX записи не проверяются
+}
+
+func dnsNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// Validate - normalize email and check that its domain can receive mail.
+// Domain without MX records receives mail by its A/AAAA records (RFC 5321), null MX (RFC 7505) does not receive it
+func (v EmailValidator) Validate(ctx context.Context, email string) (string, error) {
+	res, err := NormalizeEmail(email)
+	if err != nil || v.Resolver == nil {
+		return res, err
+	}
+	domain := res[strings.LastIndexByte(res, '@')+1:]
+	mx, err := v.Resolver.LookupMX(ctx, domain)
+	switch {
+	case err == nil && len(mx) == 1 && strings.TrimSuffix(mx[0].Host, ".") == "":
+		return "", Errors["badEmail"]
+	case err == nil && len(mx) != 0:
+		return res, nil
+	case err != nil && !dnsNotFound(err):
+		return "", err
+	}
+	hosts, err := v.Resolver.LookupHost(ctx, domain)
+	if dnsNotFound(err) || err == nil && len(hosts) == 0 {
+		return "", Errors["badEmail"]
+	}
+	if err != nil {
+		return "", err
+	}
+	return res, nil
+}
+
+// PhoneRegion - правила национального формата номеров телефонов страны
+type PhoneRegion struct {
+	CountryCode string // код страны без +
+	TrunkPrefix string // префикс внутри страны (0 для Украины)
+	NationalLen int    // количество цифр номера без кода страны и префикса
+}
+
+// PhoneRegions - known regions by ISO 3166 code
+var PhoneRegions = map[string]PhoneRegion{
+	"UA": {CountryCode: "380", TrunkPrefix: "0", NationalLen: 9},
+	"PL": {CountryCode: "48", NationalLen: 9},
+	"MD": {CountryCode: "373", TrunkPrefix: "0", NationalLen: 8},
+}
+
+// DefaultPhoneRegion - регион номеров, записанных без кода страны
+const DefaultPhoneRegion = "UA"
+
+// NormalizePhone - convert phone to E.164 (+380501234567).
+// Phone without country code is interpreted in region (DefaultPhoneRegion if empty)
+func NormalizePhone(phone, region string) (string, error) {
+	if len(region) == 0 {
+		region = DefaultPhoneRegion
+	}
+	var digits strings.Builder
+	international := false
+	for i, c := range strings.TrimSpace(phone) {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == '+' && i == 0:
+			international = true
+		case c == ' ' || c == '-' || c == '(' || c == ')' || c == '.':
+		default:
+			return "", Errors["badPhone"]
+		}
+	}
+	num := digits.String()
+	if !international && strings.HasPrefix(num, "00") {
+		international = true
+		num = num[2:]
+	}
+	if !international {
+		r, ok := PhoneRegions[region]
+		if !ok {
+			return "", Errors["badPhone"]
+		}
+		switch {
+		case len(num) == len(r.CountryCode)+r.NationalLen && strings.HasPrefix(num, r.CountryCode):
+		case len(r.TrunkPrefix) != 0 && len(num) == len(r.TrunkPrefix)+r.NationalLen && strings.HasPrefix(num, r.TrunkPrefix):
+			num = r.CountryCode + num[len(r.TrunkPrefix):]
+		case len(num) == r.NationalLen:
+			num = r.CountryCode + num
+		// Украинские номера часто записывают без первой цифры кода страны (80501234567)
+		case region == "UA" && len(num) == 11 && strings.HasPrefix(num, "80"):
+			num = "3" + num
+		default:
+			return "", Errors["badPhone"]
+		}
+	}
+	if len(num) < 8 || len(num) > 15 || num[0] == '0' {
+		return "", Errors["badPhone"]
+	}
+	for _, r := range PhoneRegions {
+		if strings.HasPrefix(num, r.CountryCode) && len(num) != len(r.CountryCode)+r.NationalLen {
+			return "", Errors["badPhone"]
+		}
+	}
+	return "+" + num, nil
+}
+
+// Веса разрядов кода ЄДРПОУ. Для кодов из диапазона 30000000-60000000 используются edrpouWeightsAlt
+var (
+	edrpouWeights    = [7]int{1, 2, 3, 4, 5, 6, 7}
+	edrpouWeightsAlt = [7]int{7, 1, 2, 3, 4, 5, 6}
+)
+
+// ValidEDRPOU - check control digit of the EDRPOU code of the company (8 digits, leading zeros are allowed)
+func ValidEDRPOU(code uint64) bool {
+	if code == 0 || code > 99999999 {
+		return false
+	}
+	var digits [8]int
+	for i, c := 7, code; i >= 0; i-- {
+		digits[i] = int(c % 10)
+		c /= 10
+	}
+	weights := edrpouWeights
+	if code >= 30000000 && code < 60000000 {
+		weights = edrpouWeightsAlt
+	}
+	checksum := func(shift int) int {
+		sum := 0
+		for i := 0; i < 7; i++ {
+			sum += digits[i] * (weights[i] + shift)
+		}
+		return sum % 11
+	}
+	control := checksum(0)
+	if control == 10 {
+		if control = checksum(2); control == 10 {
+			control = 0
+		}
+	}
+	return control == digits[7]
+}
+
+// NormalizeContacts - validate and normalize email and phone of the user and EDRPOU code of the company.
+// Return NewValidateError with all incorrect fields
+func (u *User) NormalizeContacts() error {
+	var fields []FieldError
+	if email, err := NormalizeEmail(u.Email); err != nil {
+		fields = append(fields, FieldError{Field: "email", Message: err.Error()})
+	} else {
+		u.Email = email
+	}
+	if len(u.Phone) != 0 {
+		if phone, err := NormalizePhone(u.Phone, DefaultPhoneRegion); err != nil {
+			fields = append(fields, FieldError{Field: "phone", Message: err.Error()})
+		} else {
+			u.Phone = phone
+		}
+	}
+	if u.Company.Code != 0 && !ValidEDRPOU(u.Company.Code) {
+		fields = append(fields, FieldError{Field: "company.code", Message: Errors["badCompanyCode"].Error()})
+	}
+	if len(fields) != 0 {
+		return NewValidateError(fields...)
+	}
+	return nil
+}
+
+// CheckCode - validate EDRPOU code of the company if it is set
+func (c *Company) CheckCode() error {
+	if c.Code != 0 && !ValidEDRPOU(c.Code) {
+		return NewValidateError(FieldError{Field: "code", Message: Errors["badCompanyCode"].Error()})
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	cases := []struct {
+		email, want string
+	}{
+		{" User.Name+tag@Example.COM ", "user.name+tag@example.com"},
+		{"info@пример.укр", "info@xn--e1afmkfd.xn--j1amh"},
+		{"info@ПРИМЕР.УКР", "info@xn--e1afmkfd.xn--j1amh"},
+		{"a@mail.com.", "a@mail.com"},
+		{"a..b@mail.com", ""},
+		{".a@mail.com", ""},
+		{"a b@mail.com", ""},
+		{"a@localhost", ""},
+		{"a@1.2.3.4", ""},
+		{"a@-mail.com", ""},
+		{"a@mail..com", ""},
+		{"mail.com", ""},
+		{"a@", ""},
+		{"@mail.com", ""},
+	}
+	for _, c := range cases {
+		got, err := NormalizeEmail(c.email)
+		if got != c.want || (err != nil) != (len(c.want) == 0) {
+			t.Errorf("%q: %q, %v", c.email, got, err)
+		}
+		if err != nil && !errors.Is(err, Errors["badEmail"]) {
+			t.Errorf("%q: error %v", c.email, err)
+		}
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	cases := []struct {
+		phone, region, want string
+	}{
+		{"050 123 45 67", "", "+380501234567"},
+		{"(050) 123-45-67", "UA", "+380501234567"},
+		{"80501234567", "", "+380501234567"},
+		{"380501234567", "", "+380501234567"},
+		{"+38 (050) 123-45-67", "", "+380501234567"},
+		{"00380501234567", "", "+380501234567"},
+		{"501234567", "", "+380501234567"},
+		{"+48 123 456 789", "", "+48123456789"},
+		{"123 456 789", "PL", "+48123456789"},
+		{"+3805012345", "", ""},
+		{"+38050123456789", "", ""},
+		{"050 123 45 6x", "", ""},
+		{"050+1234567", "", ""},
+		{"12345", "", ""},
+		{"", "", ""},
+		{"050 123 45 67", "PL", ""},
+		{"050 123 45 67", "XX", ""},
+	}
+	for _, c := range cases {
+		got, err := NormalizePhone(c.phone, c.region)
+		if got != c.want || (err != nil) != (len(c.want) == 0) {
+			t.Errorf("%q (%s): %q, %v", c.phone, c.region, got, err)
+		}
+	}
+}
+
+// testResolver - DNS records by domain, absent domain is not found
+type testResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+	err   error
+}
+
+func (r testResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if mx, ok := r.mx[name]; ok {
+		return mx, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r testResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if hosts, ok := r.hosts[host]; ok {
+		return hosts, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestEmailValidator(t *testing.T) {
+	resolver := testResolver{
+		mx: map[string][]*net.MX{
+			"mail.com":   {{Host: "mx.mail.com.", Pref: 10}},
+			"nomail.com": {{Host: ".", Pref: 0}},
+		},
+		hosts: map[string][]string{"host.com": {"192.0.2.1"}, "nomail.com": {"192.0.2.2"}},
+	}
+	cases := []struct {
+		email string
+		valid bool
+	}{
+		{"a@Mail.com", true},
+		{"a@host.com", true}, // без MX почта доставляется на A/AAAA запись
+		{"a@nomail.com", false},
+		{"a@absent.com", false},
+		{"bad@", false},
+	}
+	v := EmailValidator{Resolver: resolver}
+	for _, c := range cases {
+		_, err := v.Validate(context.Background(), c.email)
+		if (err == nil) != c.valid || err != nil && !errors.Is(err, Errors["badEmail"]) {
+			t.Errorf("%s: %v", c.email, err)
+		}
+	}
+	timeout := &net.DNSError{Err: "timeout", IsTimeout: true}
+	if _, err := (EmailValidator{Resolver: testResolver{err: timeout}}).Validate(context.Background(), "a@mail.com"); err != timeout {
+		t.Errorf("resolver error: %v", err)
+	}
+	if res, err := (EmailValidator{}).Validate(context.Background(), "A@Absent.com"); err != nil || res != "a@absent.com" {
+		t.Errorf("without resolver: %q, %v", res, err)
+	}
+}
+
+func TestValidEDRPOU(t *testing.T) {
+	cases := []struct {
+		code  uint64
+		valid bool
+	}{
+		{14360570, true}, // ПриватБанк
+		{20077720, true}, // Нафтогаз
+		{21560045, true}, // Укрпошта
+		{32129, true},    // Ощадбанк, код с ведущими нулями 00032129
+		{30370711, true}, // диапазон 30000000-60000000, другие веса
+		{40075815, true},
+		{10000062, true}, // контрольная сумма 10, повторный расчет с весами +2
+		{14360571, false},
+		{20077721, false},
+		{30370712, false},
+		{10000063, false},
+		{0, false},
+		{100000000, false},
+	}
+	for _, c := range cases {
+		if got := ValidEDRPOU(c.code); got != c.valid {
+			t.Errorf("%08d: %v, want %v", c.code, got, c.valid)
+		}
+	}
+}
+
+func TestCompanyCheckCode(t *testing.T) {
+	if err := (&Company{}).CheckCode(); err != nil {
+		t.Fatal("empty code rejected: ", err)
+	}
+	if err := (&Company{Code: 14360570}).CheckCode(); err != nil {
+		t.Fatal(err)
+	}
+	err := (&Company{Code: 14360571}).CheckCode()
+	var e EgeonError
+	if !errors.As(err, &e) || e.Code != ValidateError {
+		t.Fatalf("got %v", err)
+	}
+	if f := e.FieldList(); len(f) != 1 || f[0].Field != "code" || f[0].Message != Errors["badCompanyCode"].Error() {
+		t.Fatalf("fields %+v", f)
+	}
+}
@@ -0,0 +1,184 @@
+package golang
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FromSQLStats - convert statistics of database/sql pool to DBStats
+func FromSQLStats(s sql.DBStats) DBStats {
+	return DBStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDuration:       s.WaitDuration,
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
+
+// PgxPoolStat - statistics of the pgx pool (*pgxpool.Stat implements it)
+type PgxPoolStat interface {
+	MaxConns() int32
+	TotalConns() int32
+	AcquiredConns() int32
+	IdleConns() int32
+	EmptyAcquireCount() int64       // Acquires that waited for a connection
+	AcquireDuration() time.Duration // Total duration of all successful acquires, not only waiting ones
+}
+
+// FromPgxStats - convert statistics of pgx pool to DBStats.
+// pgx не считает время ожидания соединения, поэтому WaitDuration остается нулевым, а общее время получения соединений
+// попадает в AcquireDuration
+func FromPgxStats(s PgxPoolStat) DBStats {
+	return DBStats{
+		MaxOpenConnections: int(s.MaxConns()),
+		OpenConnections:    int(s.TotalConns()),
+		InUse:              int(s.AcquiredConns()),
+		Idle:               int(s.IdleConns()),
+		WaitCount:          s.EmptyAcquireCount(),
+		AcquireDuration:    s.AcquireDuration(),
+	}
+}
+
+// DBStatsFunc - source of the pool statistics
+type DBStatsFunc func() DBStats
+
+// SQLStatsSource - source of statistics for *sql.DB
+func SQLStatsSource(db *sql.DB) DBStatsFunc {
+	return func() DBStats { return FromSQLStats(db.Stats()) }
+}
+
+// PgxStatsSource - source of statistics for pgx pool. Use it as PgxStatsSource(func() PgxPoolStat { return pool.Stat() })
+func PgxStatsSource(stat func() PgxPoolStat) DBStatsFunc {
+	return func() DBStats { return FromPgxStats(stat()) }
+}
+
+// DBPoolOptions - thresholds of the pool health warning
+type DBPoolOptions struct {
+	MaxWaitPerSecond     float64       // Допустимый прирост WaitCount в секунду (0 - не проверяется)
+	MaxWaitTimePerSecond time.Duration // Допустимый прирост WaitDuration в секунду (0 - не проверяется, для pgx не работает)
+}
+
+// DBPool - named database pool which statistics are published in server status, metrics and health checks
+type DBPool struct {
+	Name   string
+	source DBStatsFunc
+	opt    DBPoolOptions
+	mt     sync.Mutex
+	last   DBStats
+	lastAt time.Time
+}
+
+var dbPools = make(map[string]*DBPool) // Все зарегистрированные пулы соединений
+var dbPoolsMt sync.RWMutex
+
+// ErrDBPoolExists - pool with the same name is already registered
+var ErrDBPoolExists = errors.New("database pool already registered")
+
+// RegisterDBPool - register pool with name. Its statistics will be added to server status and DefaultMetrics.
+// Return ErrDBPoolExists if name is already used
+func RegisterDBPool(name string, source DBStatsFunc, opt DBPoolOptions) (*DBPool, error) {
+	dbPoolsMt.Lock()
+	defer dbPoolsMt.Unlock()
+	if _, ok := dbPools[name]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrDBPoolExists, name)
+	}
+	pool := &DBPool{Name: name, source: source, opt: opt}
+	dbPools[name] = pool
+	return pool, nil
+}
+
+// Unregister - remove pool from server status and metrics (call it when pool is closed)
+func (p *DBPool) Unregister() {
+	dbPoolsMt.Lock()
+	if dbPools[p.Name] == p {
+		delete(dbPools, p.Name)
+		for _, g := range dbPoolGauges {
+			g.Delete(p.Name)
+		}
+	}
+	dbPoolsMt.Unlock()
+}
+
+// Stats - current statistics of the pool
+func (p *DBPool) Stats() DBStats {
+	return p.source()
+}
+
+// HealthCheck - return warning when WaitCount or WaitDuration grow faster than thresholds from DBPoolOptions
+func (p *DBPool) HealthCheck() HealthCheckFunc {
+	return func(ctx context.Context) error {
+		now := time.Now()
+		st := p.source()
+		p.mt.Lock()
+		prev, prevAt := p.last, p.lastAt
+		p.last, p.lastAt = st, now
+		p.mt.Unlock()
+		if prevAt.IsZero() {
+			return nil
+		}
+		seconds := now.Sub(prevAt).Seconds()
+		if seconds <= 0 {
+			return nil
+		}
+		if rate := float64(st.WaitCount-prev.WaitCount) / seconds; p.opt.MaxWaitPerSecond > 0 && rate > p.opt.MaxWaitPerSecond {
+			return HealthWarning{Description: fmt.Sprintf("pool %s: wait count grows %.2f per second", p.Name, rate)}
+		}
+		if rate := time.Duration(float64(st.WaitDuration-prev.WaitDuration) / seconds); p.opt.MaxWaitTimePerSecond > 0 && rate > p.opt.MaxWaitTimePerSecond {
+			return HealthWarning{Description: fmt.Sprintf("pool %s: wait duration grows %s per second", p.Name, rate)}
+		}
+		return nil
+	}
+}
+
+// DBPoolsStats - statistics of all registered pools by name
+func DBPoolsStats() map[string]DBStats {
+	dbPoolsMt.RLock()
+	defer dbPoolsMt.RUnlock()
+	if len(dbPools) == 0 {
+		return nil
+	}
+	res := make(map[string]DBStats, len(dbPools))
+	for name, pool := range dbPools {
+		res[name] = pool.Stats()
+	}
+	return res
+}
+
+// dbPoolGauges - gauges of DefaultMetrics with series per pool
+var dbPoolGauges []GaugeVec
+
+func init() {
+	r := DefaultMetrics.Registry
+	maxOpen := r.NewGauge("db_pool_max_open_connections", "Maximum number of open connections to the database", "pool")
+	open := r.NewGauge("db_pool_open_connections", "The number of established connections both in use and idle", "pool")
+	inUse := r.NewGauge("db_pool_in_use_connections", "The number of connections currently in use", "pool")
+	idle := r.NewGauge("db_pool_idle_connections", "The number of idle connections", "pool")
+	waitCount := r.NewGauge("db_pool_wait_count", "The total number of connections waited for", "pool")
+	waitDuration := r.NewGauge("db_pool_wait_duration_seconds", "The total time blocked waiting for a new connection", "pool")
+	acquireDuration := r.NewGauge("db_pool_acquire_duration_seconds", "The total duration of all successful acquires", "pool")
+	dbPoolGauges = []GaugeVec{maxOpen, open, inUse, idle, waitCount, waitDuration, acquireDuration}
+	r.OnCollect(func() {
+		// блокировка не дает вернуть серии пула, удаленного во время сбора
+		dbPoolsMt.RLock()
+		defer dbPoolsMt.RUnlock()
+		for name, pool := range dbPools {
+			st := pool.Stats()
+			maxOpen.Set(float64(st.MaxOpenConnections), name)
+			open.Set(float64(st.OpenConnections), name)
+			inUse.Set(float64(st.InUse), name)
+			idle.Set(float64(st.Idle), name)
+			waitCount.Set(float64(st.WaitCount), name)
+			waitDuration.Set(st.WaitDuration.Seconds(), name)
+			acquireDuration.Set(st.AcquireDuration.Seconds(), name)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package golang
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type pgxStat struct{}
+
+func (pgxStat) MaxConns() int32                { return 10 }
+func (pgxStat) TotalConns() int32              { return 4 }
+func (pgxStat) AcquiredConns() int32           { return 3 }
+func (pgxStat) IdleConns() int32               { return 1 }
+func (pgxStat) EmptyAcquireCount() int64       { return 2 }
+func (pgxStat) AcquireDuration() time.Duration { return time.Second }
+
+func TestFromPgxStats(t *testing.T) {
+	st := FromPgxStats(pgxStat{})
+	if st.WaitDuration != 0 || st.AcquireDuration != time.Second || st.WaitCount != 2 || st.InUse != 3 {
+		t.Fatalf("%+v", st)
+	}
+}
+
+func TestRegisterDBPoolDuplicate(t *testing.T) {
+	source := func() DBStats { return DBStats{InUse: 1} }
+	pool, err := RegisterDBPool("test", source, DBPoolOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = RegisterDBPool("test", source, DBPoolOptions{}); !errors.Is(err, ErrDBPoolExists) {
+		t.Fatalf("duplicate pool: %v", err)
+	}
+	if DBPoolsStats()["test"].InUse != 1 {
+		t.Fatal("pool stats missing")
+	}
+	pool.Unregister()
+	if _, ok := DBPoolsStats()["test"]; ok {
+		t.Fatal("pool is not unregistered")
+	}
+	if pool, err = RegisterDBPool("test", source, DBPoolOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	pool.Unregister()
+}
+
+func TestUnregisterDBPoolMetrics(t *testing.T) {
+	pool, err := RegisterDBPool("metricsTest", func() DBStats { return DBStats{InUse: 2} }, DBPoolOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	series := `db_pool_in_use_connections{pool="metricsTest"} 2`
+	var out strings.Builder
+	DefaultMetrics.Registry.WriteText(&out)
+	if !strings.Contains(out.String(), series) {
+		t.Fatalf("no pool series in\n%s", out.String())
+	}
+	pool.Unregister()
+	out.Reset()
+	DefaultMetrics.Registry.WriteText(&out)
+	if strings.Contains(out.String(), `pool="metricsTest"`) {
+		t.Fatalf("pool series after Unregister\n%s", out.String())
+	}
+}
@@ -24,6 +24,7 @@ type DBStats struct {
 	// Counters
 	WaitCount         int64         `json:"waitCon"`           // The total number of connections waited for.
 	WaitDuration      time.Duration `json:"waitDuration"`      // The total time blocked waiting for a new connection.
+	AcquireDuration   time.Duration `json:"acquireDuration"`   // The total duration of all successful acquires (pgx), including acquires without waiting.
 	MaxIdleClosed     int64         `json:"maxIdleClosed"`     // The total number of connections closed due to SetMaxIdleConns.
 	MaxIdleTimeClosed int64         `json:"maxIdleTimeClosed"` // The total number of connections closed due to SetConnMaxIdleTime.
 	MaxLifetimeClosed int64         `json:"maxLifetimeClosed"` // The total number of connections closed due to SetConnMaxLifetime.
@@ -31,18 +32,38 @@ type DBStats struct {
 
 //ServerStatus - полная инфомация о сервисе в системе Егеон
 type ServerStatus struct {
-	Info          ServerInfo             `json:"info,omitempty"`
-	Addition      map[string]interface{} `json:"addition,omitempty"`
-	StartDate     time.Time              `json:"startDate,omitempty"`
-	UpTime        time.Duration          `json:"upTime,omitempty"`
-	UpTimeStr     string                 `json:"upTimeStr,omitempty"`
-	SuccesReqCnt  uint64                 `json:"succesReqCnt,omitempty"`
-	FaileReqCnt   uint64                 `json:"faileReqCnt,omitempty"`
-	FaileGetCnt   uint64                 `json:"faileGetCnt,omitempty"`
-	FailePostCnt  uint64                 `json:"failePostCnt,omitempty"`
-	FailePutCnt   uint64                 `json:"failePutCnt,omitempty"`
-	FaileDelCnt   uint64                 `json:"faileDelCnt,omitempty"`
-	MiddleReqTime int64                  `json:"middleReqTime,omitempty"`
+	Info          ServerInfo              `json:"info,omitempty"`
+	Addition      map[string]interface{}  `json:"addition,omitempty"`
+	StartDate     time.Time               `json:"startDate,omitempty"`
+	UpTime        time.Duration           `json:"upTime,omitempty"`
+	UpTimeStr     string                  `json:"upTimeStr,omitempty"`
+	SuccesReqCnt  uint64                  `json:"succesReqCnt,omitempty"`
+	FaileReqCnt   uint64                  `json:"faileReqCnt,omitempty"`
+	FaileGetCnt   uint64                  `json:"faileGetCnt,omitempty"`
+	FailePostCnt  uint64                  `json:"failePostCnt,omitempty"`
+	FailePutCnt   uint64                  `json:"failePutCnt,omitempty"`
+	FaileDelCnt   uint64                  `json:"faileDelCnt,omitempty"`
+	MiddleReqTime int64                   `json:"middleReqTime,omitempty"`
+	Methods       map[string]MethodStats  `json:"methods,omitempty"` // Статистика по каждому методу запроса
+	Latency       map[string]LatencyStats `json:"latency,omitempty"` // Перцентили времени ответа за окна 1m, 5m, 15m
+}
+
+// MethodStats - статистика запросов одного метода. Запрос неуспешный если он прерван или его статус 5xx
+type MethodStats struct {
+	SuccessCnt uint64 `json:"successCnt"`
+	FailedCnt  uint64 `json:"failedCnt"`
+	Status2xx  uint64 `json:"status2xx,omitempty"`
+	Status3xx  uint64 `json:"status3xx,omitempty"`
+	Status4xx  uint64 `json:"status4xx,omitempty"`
+	Status5xx  uint64 `json:"status5xx,omitempty"`
+}
+
+// LatencyStats - перцентили времени ответа за скользящее окно
+type LatencyStats struct {
+	Count uint64        `json:"count"`
+	P50   time.Duration `json:"p50"`
+	P95   time.Duration `json:"p95"`
+	P99   time.Duration `json:"p99"`
 }
 
 /*
@@ -148,7 +169,18 @@ type RoleSets struct {
 // SessionKey - Создание нового типа для ключа сессии позволяет осуществлять switch case по типу
 type SessionKey string
 
-// UsersGroup - TODO create user groups interface in the futer (пока не реализовано)
+// Session - активная сессия пользователя
+type Session struct {
+	Key          SessionKey `json:"key"`
+	UserID       uint32     `json:"userId"`
+	IP           string     `json:"ip,omitempty"`
+	UserAgent    string     `json:"browser,omitempty"`
+	AddedDate    time.Time  `json:"addedDate"`
+	LastActivity time.Time  `json:"lastActivity"`
+	ExpireDate   time.Time  `json:"expired"`
+}
+
+// UsersGroup - членство пользователя в группе и его права на ресурсы группы (см. PermissionEngine)
 type UsersGroup struct {
 	UserID       uint32    `json:"userId"`
 	Group        Group     `json:"group,omitempty"`
@@ -161,6 +193,7 @@ type UsersGroup struct {
 }
 
 // User - пользователи системы
+// JSON содержит хеш пароля для обмена между сервисами, ответы клиентам кодируйте через PublicJSON
 type User struct {
 	ID                uint32       `json:"id"`
 	Profile           UserProfile  `json:"profile,omitempty"`
@@ -172,6 +205,7 @@ type User struct {
 	Company           Company      `json:"company,omitempty"`
 	IsComapanyConfirm bool         `json:"isCompanyConfirm,omitempty"`
 	AccessFailedCount int          `json:"accesFailedCnt,omitempty"`
+	LastFailedLogin   time.Time    `json:"lastFailedLogin,omitempty"`
 	RestorePassword   bool         `json:"restorePassword,omitempty"`
 	LastActivity      time.Time    `json:"lastActivity,omitempty"`
 	AddedDate         time.Time    `json:"addedDate,omitempty"`
@@ -183,6 +217,18 @@ type User struct {
 	ExpireDate        time.Time    `json:"expired,omitempty"`
 }
 
+// Principal - компактное представление пользователя, которое передается между сервисами в заголовке запроса
+// Не содержит профиль, пароль и полные данные компании и ролей
+type Principal struct {
+	ID         uint32     `json:"id"`
+	Email      string     `json:"email"`
+	CompanyID  uint32     `json:"companyId,omitempty"`
+	RoleIDs    []uint32   `json:"roleIds,omitempty"`
+	RoleNames  []string   `json:"roleNames,omitempty"`
+	SessionKey SessionKey `json:"sessionKey,omitempty"`
+	ExpireDate time.Time  `json:"expired,omitempty"`
+}
+
 //UserProfile - хранит данные профиля
 type UserProfile struct {
 	ID             uint32    `json:"id,omitempty"`
@@ -227,3 +273,60 @@ type UserLog struct {
 	ResponceTime time.Duration `json:"responceTime"`
 	AddeDate     time.Time     `json:"addedDate"`
 }
+
+// PageInfo - информация о странице списка
+type PageInfo struct {
+	Total      int64  `json:"total"`                // Общее количество записей (-1 если не подсчитывается)
+	Limit      int    `json:"limit"`                // Максимальное количество записей на странице
+	Offset     int    `json:"offset,omitempty"`     // Смещение страницы (при постраничной навигации по смещению)
+	NextCursor string `json:"nextCursor,omitempty"` // Курсор следующей страницы, пустой если это последняя страница
+}
+
+// GroupPage - страница списка групп
+type GroupPage struct {
+	Items []Group `json:"items"`
+	PageInfo
+}
+
+// CompanyPage - страница списка компаний
+type CompanyPage struct {
+	Items []Company `json:"items"`
+	PageInfo
+}
+
+// UserPage - страница списка пользователей
+type UserPage struct {
+	Items []User `json:"items"`
+	PageInfo
+}
+
+// UserLogPage - страница журнала активности пользователей
+type UserLogPage struct {
+	Items []UserLog `json:"items"`
+	PageInfo
+}
+
+// FieldChange - изменение поля сущности. Field - путь к полю по json тегам (например address.city)
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// AuditRecord - запись истории изменений сущности
+type AuditRecord struct {
+	ID         uint64        `json:"id,omitempty"`
+	EntityType string        `json:"entityType"`
+	EntityID   uint64        `json:"entityId"`
+	Action     uint32        `json:"action"` // Inserted, Updated или Deleted
+	ActorID    uint32        `json:"actorId,omitempty"`
+	RequestID  string        `json:"requestId,omitempty"`
+	Changes    []FieldChange `json:"changes,omitempty"`
+	AddedDate  time.Time     `json:"addedDate"`
+}
+
+// AuditPage - страница истории изменений сущности
+type AuditPage struct {
+	Items []AuditRecord `json:"items"`
+	PageInfo
+}
@@ -0,0 +1,166 @@
+package golang
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var postCodeRe = regexp.MustCompile(`^\d{5}$`)
+
+// IsUkraine - country of the address is Ukraine (ISO code or name)
+func IsUkraine(country string) bool {
+	switch strings.ToLower(strings.TrimSpace(country)) {
+	case "ua", "ukr", "україна", "украина", "ukraine":
+		return true
+	}
+	return false
+}
+
+// Наборы правил проверки DTO. Сервисы могут дополнять их своими правилами
+var (
+	StatusRules = MustRuleSet(Status{}, RuleSet{
+		{Field: "name", Rules: []Rule{Length(0, 100)}},
+		{Field: "description", Rules: []Rule{Length(0, 1024)}},
+	})
+
+	CommentRules = MustRuleSet(Comment{}, RuleSet{
+		{Field: "id", Rules: []Rule{Required()}, On: OpUpdate},
+		{Field: "head", Rules: []Rule{Length(0, 255)}},
+		{Field: "data", Rules: []Rule{Required(), Length(1, 65535)}},
+		{Field: "type", Rules: []Rule{Length(0, 100)}},
+		{Field: "status", Rules: []Rule{Nested(StatusRules)}},
+	})
+
+	AddressRules = MustRuleSet(Address{}, RuleSet{
+		{Field: "id", Rules: []Rule{Required()}, On: OpUpdate},
+		{Field: "country", Rules: []Rule{Length(0, 100)}},
+		{Field: "region", Rules: []Rule{Length(0, 100)}},
+		{Field: "city", Rules: []Rule{Required(), Length(1, 100)}},
+		{Field: "district", Rules: []Rule{Length(0, 100)}},
+		{Field: "microDistrict", Rules: []Rule{Length(0, 100)}},
+		{Field: "street", Rules: []Rule{Length(0, 255)}},
+		{Field: "build", Rules: []Rule{Length(0, 20)}},
+		{Field: "apartment", Rules: []Rule{Length(0, 20)}},
+		{Field: "lat", Rules: []Rule{Range(-90, 90)}},
+		{Field: "lng", Rules: []Rule{Range(-180, 180)}},
+		// 5-значный индекс только для адресов в Украине
+		{Field: "postCode", Rules: []Rule{Match(postCodeRe)}, When: func(obj interface{}) bool {
+			a, ok := obj.(Address)
+			return ok && IsUkraine(a.Country)
+		}},
+		{Field: "comment", Rules: []Rule{Nested(CommentRules)}},
+		{Field: "status", Rules: []Rule{Nested(StatusRules)}},
+		{Field: "fullName", Rules: []Rule{Length(0, 1024)}},
+	})
+
+	CompanyRules = MustRuleSet(Company{}, RuleSet{
+		{Field: "id", Rules: []Rule{Required()}, On: OpUpdate},
+		{Field: "name", Rules: []Rule{Required(), Length(1, 255)}},
+		{Field: "description", Rules: []Rule{Length(0, 4096)}},
+		{Field: "code", Rules: []Rule{EDRPOU()}},
+		{Field: "address", Rules: []Rule{Nested(AddressRules)}},
+		{Field: "status", Rules: []Rule{Nested(StatusRules)}},
+	})
+
+	GroupRules = MustRuleSet(Group{}, RuleSet{
+		{Field: "id", Rules: []Rule{Required()}, On: OpUpdate},
+		{Field: "name", Rules: []Rule{Required(), Length(1, 255)}},
+		{Field: "description", Rules: []Rule{Length(0, 4096)}},
+		{Field: "status", Rules: []Rule{Nested(StatusRules)}},
+	})
+
+	RoleRules = MustRuleSet(Role{}, RuleSet{
+		{Field: "id", Rules: []Rule{Required()}, On: OpUpdate},
+		{Field: "name", Rules: []Rule{Required(), Length(1, 100)}},
+		{Field: "url", Rules: []Rule{Required(), Length(1, 1024)}},
+		{Field: "method", Rules: []Rule{Required(), OneOf(http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+			http.MethodDelete, http.MethodHead, http.MethodOptions, "*")}},
+		{Field: "description", Rules: []Rule{Length(0, 1024)}},
+		{Field: "status", Rules: []Rule{Nested(StatusRules)}},
+	})
+
+	UserProfileRules = MustRuleSet(UserProfile{}, RuleSet{
+		{Field: "lastName", Rules: []Rule{Length(0, 100)}},
+		{Field: "name", Rules: []Rule{Length(0, 100)}},
+		{Field: "nick", Rules: []Rule{Length(0, 100)}},
+		{Field: "info", Rules: []Rule{Length(0, 4096)}},
+		{Field: "country", Rules: []Rule{Length(0, 100)}},
+		{Field: "region", Rules: []Rule{Length(0, 100)}},
+		{Field: "city", Rules: []Rule{Length(0, 100)}},
+		{Field: "lat", Rules: []Rule{Range(-90, 90)}},
+		{Field: "lng", Rules: []Rule{Range(-180, 180)}},
+	})
+
+	// roleRefRules - роль, на которую ссылается пользователь или токен (достаточно идентификатора)
+	roleRefRules = MustRuleSet(Role{}, RuleSet{
+		{Field: "id", Rules: []Rule{Required()}},
+	})
+
+	UserRules = MustRuleSet(User{}, RuleSet{
+		{Field: "id", Rules: []Rule{Required()}, On: OpUpdate},
+		{Field: "email", Rules: []Rule{Required(), Email()}},
+		{Field: "phone", Rules: []Rule{Phone()}},
+		{Field: "profile", Rules: []Rule{Nested(UserProfileRules)}},
+		// Компания пользователя часто содержит только идентификатор, поэтому проверяется только код
+		{Field: "company", Rules: []Rule{Nested(MustRuleSet(Company{}, RuleSet{{Field: "code", Rules: []Rule{EDRPOU()}}}))}},
+		{Field: "roles", Rules: []Rule{Each(Nested(roleRefRules))}},
+		{Field: "status", Rules: []Rule{Nested(StatusRules)}},
+	})
+
+	APITokenRules = MustRuleSet(APIToken{}, RuleSet{
+		{Field: "id", Rules: []Rule{Required()}, On: OpUpdate},
+		{Field: "ownerId", Rules: []Rule{Required()}, On: OpCreate},
+		{Field: "description", Rules: []Rule{Length(0, 1024)}},
+		{Field: "roles", Rules: []Rule{Each(Nested(roleRefRules))}},
+		{Field: "expired", Rules: []Rule{Required(), Future()}, On: OpCreate},
+	})
+)
+
+// Validate - check address by AddressRules (rules common for all operations)
+func (a *Address) Validate() error { return AddressRules.Validate(a, OpAny) }
+
+// ValidateFor - check address by AddressRules for operation op
+func (a *Address) ValidateFor(op Operation) error { return AddressRules.Validate(a, op) }
+
+// Validate - check company by CompanyRules (rules common for all operations)
+func (c *Company) Validate() error { return CompanyRules.Validate(c, OpAny) }
+
+// ValidateFor - check company by CompanyRules for operation op
+func (c *Company) ValidateFor(op Operation) error { return CompanyRules.Validate(c, op) }
+
+// Validate - check group by GroupRules (rules common for all operations)
+func (g *Group) Validate() error { return GroupRules.Validate(g, OpAny) }
+
+// ValidateFor - check group by GroupRules for operation op
+func (g *Group) ValidateFor(op Operation) error { return GroupRules.Validate(g, op) }
+
+// Validate - check role by RoleRules (rules common for all operations)
+func (r *Role) Validate() error { return RoleRules.Validate(r, OpAny) }
+
+// ValidateFor - check role by RoleRules for operation op
+func (r *Role) ValidateFor(op Operation) error { return RoleRules.Validate(r, op) }
+
+// Validate - check user by UserRules (rules common for all operations)
+func (u *User) Validate() error { return UserRules.Validate(u, OpAny) }
+
+// ValidateFor - check user by UserRules for operation op
+func (u *User) ValidateFor(op Operation) error { return UserRules.Validate(u, op) }
+
+// Validate - check profile by UserProfileRules
+func (p *UserProfile) Validate() error { return UserProfileRules.Validate(p, OpAny) }
+
+// ValidateFor - check profile by UserProfileRules for operation op
+func (p *UserProfile) ValidateFor(op Operation) error { return UserProfileRules.Validate(p, op) }
+
+// Validate - check token by APITokenRules (rules common for all operations)
+func (t *APIToken) Validate() error { return APITokenRules.Validate(t, OpAny) }
+
+// ValidateFor - check token by APITokenRules for operation op
+func (t *APIToken) ValidateFor(op Operation) error { return APITokenRules.Validate(t, op) }
+
+// Validate - check comment by CommentRules (rules common for all operations)
+func (c *Comment) Validate() error { return CommentRules.Validate(c, OpAny) }
+
+// ValidateFor - check comment by CommentRules for operation op
+func (c *Comment) ValidateFor(op Operation) error { return CommentRules.Validate(c, op) }
@@ -352,7 +352,259 @@ func (v *UserProfile) UnmarshalJSON(data []byte) error {
 func (v *UserProfile) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang1(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang2(in *jlexer.Lexer, out *UserLog) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang2(in *jlexer.Lexer, out *UserPage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]User, 0, 0)
+					} else {
+						out.Items = []User{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 User
+					(v1).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "total":
+			out.Total = int64(in.Int64())
+		case "limit":
+			out.Limit = int(in.Int())
+		case "offset":
+			out.Offset = int(in.Int())
+		case "nextCursor":
+			out.NextCursor = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang2(out *jwriter.Writer, in UserPage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix[1:])
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Items {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Total))
+	}
+	{
+		const prefix string = ",\"limit\":"
+		out.RawString(prefix)
+		out.Int(int(in.Limit))
+	}
+	if in.Offset != 0 {
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix)
+		out.Int(int(in.Offset))
+	}
+	if in.NextCursor != "" {
+		const prefix string = ",\"nextCursor\":"
+		out.RawString(prefix)
+		out.String(string(in.NextCursor))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserPage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserPage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserPage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserPage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang2(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang3(in *jlexer.Lexer, out *UserLogPage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]UserLog, 0, 0)
+					} else {
+						out.Items = []UserLog{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 UserLog
+					(v4).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "total":
+			out.Total = int64(in.Int64())
+		case "limit":
+			out.Limit = int(in.Int())
+		case "offset":
+			out.Offset = int(in.Int())
+		case "nextCursor":
+			out.NextCursor = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang3(out *jwriter.Writer, in UserLogPage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix[1:])
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Items {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Total))
+	}
+	{
+		const prefix string = ",\"limit\":"
+		out.RawString(prefix)
+		out.Int(int(in.Limit))
+	}
+	if in.Offset != 0 {
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix)
+		out.Int(int(in.Offset))
+	}
+	if in.NextCursor != "" {
+		const prefix string = ",\"nextCursor\":"
+		out.RawString(prefix)
+		out.String(string(in.NextCursor))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserLogPage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserLogPage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserLogPage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserLogPage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang3(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang4(in *jlexer.Lexer, out *UserLog) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -403,7 +655,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang2(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang2(out *jwriter.Writer, in UserLog) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang4(out *jwriter.Writer, in UserLog) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -463,27 +715,27 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang2(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v UserLog) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang2(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserLog) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang2(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserLog) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang2(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserLog) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang2(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang4(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang3(in *jlexer.Lexer, out *User) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang5(in *jlexer.Lexer, out *User) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -522,6 +774,10 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang3(in *jlexer.Lexer, out *
 			out.IsComapanyConfirm = bool(in.Bool())
 		case "accesFailedCnt":
 			out.AccessFailedCount = int(in.Int())
+		case "lastFailedLogin":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.LastFailedLogin).UnmarshalJSON(data))
+			}
 		case "restorePassword":
 			out.RestorePassword = bool(in.Bool())
 		case "lastActivity":
@@ -554,9 +810,9 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang3(in *jlexer.Lexer, out *
 					out.Roles = (out.Roles)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 Role
-					(v1).UnmarshalEasyJSON(in)
-					out.Roles = append(out.Roles, v1)
+					var v7 Role
+					(v7).UnmarshalEasyJSON(in)
+					out.Roles = append(out.Roles, v7)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -577,9 +833,9 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang3(in *jlexer.Lexer, out *
 					out.UsersGroups = (out.UsersGroups)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v2 UsersGroup
-					(v2).UnmarshalEasyJSON(in)
-					out.UsersGroups = append(out.UsersGroups, v2)
+					var v8 UsersGroup
+					(v8).UnmarshalEasyJSON(in)
+					out.UsersGroups = append(out.UsersGroups, v8)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -600,7 +856,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang3(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang3(out *jwriter.Writer, in User) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang5(out *jwriter.Writer, in User) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -654,6 +910,11 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang3(out *jwriter.Writer, in
 		out.RawString(prefix)
 		out.Int(int(in.AccessFailedCount))
 	}
+	if true {
+		const prefix string = ",\"lastFailedLogin\":"
+		out.RawString(prefix)
+		out.Raw((in.LastFailedLogin).MarshalJSON())
+	}
 	if in.RestorePassword {
 		const prefix string = ",\"restorePassword\":"
 		out.RawString(prefix)
@@ -684,11 +945,11 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang3(out *jwriter.Writer, in
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v3, v4 := range in.Roles {
-				if v3 > 0 {
+			for v9, v10 := range in.Roles {
+				if v9 > 0 {
 					out.RawByte(',')
 				}
-				(v4).MarshalEasyJSON(out)
+				(v10).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -698,11 +959,11 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang3(out *jwriter.Writer, in
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v5, v6 := range in.UsersGroups {
-				if v5 > 0 {
+			for v11, v12 := range in.UsersGroups {
+				if v11 > 0 {
 					out.RawByte(',')
 				}
-				(v6).MarshalEasyJSON(out)
+				(v12).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -723,27 +984,27 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang3(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v User) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang3(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v User) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang3(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *User) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang3(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *User) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang3(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang5(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang4(in *jlexer.Lexer, out *Status) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang6(in *jlexer.Lexer, out *Status) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -778,7 +1039,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang4(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang4(out *jwriter.Writer, in Status) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang6(out *jwriter.Writer, in Status) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -814,27 +1075,27 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang4(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v Status) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang4(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Status) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang4(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Status) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang4(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Status) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang4(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang6(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang5(in *jlexer.Lexer, out *ServerStatus) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang7(in *jlexer.Lexer, out *Session) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -853,56 +1114,210 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang5(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "info":
-			(out.Info).UnmarshalEasyJSON(in)
-		case "addition":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				in.Delim('{')
-				if !in.IsDelim('}') {
-					out.Addition = make(map[string]interface{})
-				} else {
-					out.Addition = nil
-				}
-				for !in.IsDelim('}') {
-					key := string(in.String())
-					in.WantColon()
-					var v7 interface{}
-					if m, ok := v7.(easyjson.Unmarshaler); ok {
-						m.UnmarshalEasyJSON(in)
-					} else if m, ok := v7.(json.Unmarshaler); ok {
-						_ = m.UnmarshalJSON(in.Raw())
-					} else {
-						v7 = in.Interface()
-					}
-					(out.Addition)[key] = v7
-					in.WantComma()
-				}
-				in.Delim('}')
+		case "key":
+			out.Key = SessionKey(in.String())
+		case "userId":
+			out.UserID = uint32(in.Uint32())
+		case "ip":
+			out.IP = string(in.String())
+		case "browser":
+			out.UserAgent = string(in.String())
+		case "addedDate":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.AddedDate).UnmarshalJSON(data))
 			}
-		case "startDate":
+		case "lastActivity":
 			if data := in.Raw(); in.Ok() {
-				in.AddError((out.StartDate).UnmarshalJSON(data))
+				in.AddError((out.LastActivity).UnmarshalJSON(data))
 			}
-		case "upTime":
-			out.UpTime = time.Duration(in.Int64())
-		case "upTimeStr":
-			out.UpTimeStr = string(in.String())
-		case "succesReqCnt":
-			out.SuccesReqCnt = uint64(in.Uint64())
-		case "faileReqCnt":
-			out.FaileReqCnt = uint64(in.Uint64())
-		case "faileGetCnt":
-			out.FaileGetCnt = uint64(in.Uint64())
-		case "failePostCnt":
-			out.FailePostCnt = uint64(in.Uint64())
+		case "expired":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ExpireDate).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang7(out *jwriter.Writer, in Session) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"key\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Key))
+	}
+	{
+		const prefix string = ",\"userId\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.UserID))
+	}
+	if in.IP != "" {
+		const prefix string = ",\"ip\":"
+		out.RawString(prefix)
+		out.String(string(in.IP))
+	}
+	if in.UserAgent != "" {
+		const prefix string = ",\"browser\":"
+		out.RawString(prefix)
+		out.String(string(in.UserAgent))
+	}
+	{
+		const prefix string = ",\"addedDate\":"
+		out.RawString(prefix)
+		out.Raw((in.AddedDate).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"lastActivity\":"
+		out.RawString(prefix)
+		out.Raw((in.LastActivity).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"expired\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpireDate).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Session) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Session) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Session) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Session) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang7(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang8(in *jlexer.Lexer, out *ServerStatus) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "info":
+			(out.Info).UnmarshalEasyJSON(in)
+		case "addition":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Addition = make(map[string]interface{})
+				} else {
+					out.Addition = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v13 interface{}
+					if m, ok := v13.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v13.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v13 = in.Interface()
+					}
+					(out.Addition)[key] = v13
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "startDate":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.StartDate).UnmarshalJSON(data))
+			}
+		case "upTime":
+			out.UpTime = time.Duration(in.Int64())
+		case "upTimeStr":
+			out.UpTimeStr = string(in.String())
+		case "succesReqCnt":
+			out.SuccesReqCnt = uint64(in.Uint64())
+		case "faileReqCnt":
+			out.FaileReqCnt = uint64(in.Uint64())
+		case "faileGetCnt":
+			out.FaileGetCnt = uint64(in.Uint64())
+		case "failePostCnt":
+			out.FailePostCnt = uint64(in.Uint64())
 		case "failePutCnt":
 			out.FailePutCnt = uint64(in.Uint64())
 		case "faileDelCnt":
 			out.FaileDelCnt = uint64(in.Uint64())
 		case "middleReqTime":
 			out.MiddleReqTime = int64(in.Int64())
+		case "methods":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Methods = make(map[string]MethodStats)
+				} else {
+					out.Methods = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v14 MethodStats
+					(v14).UnmarshalEasyJSON(in)
+					(out.Methods)[key] = v14
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "latency":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Latency = make(map[string]LatencyStats)
+				} else {
+					out.Latency = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v15 LatencyStats
+					(v15).UnmarshalEasyJSON(in)
+					(out.Latency)[key] = v15
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -913,7 +1328,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang5(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang5(out *jwriter.Writer, in ServerStatus) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang8(out *jwriter.Writer, in ServerStatus) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -933,21 +1348,21 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang5(out *jwriter.Writer, in
 		}
 		{
 			out.RawByte('{')
-			v8First := true
-			for v8Name, v8Value := range in.Addition {
-				if v8First {
-					v8First = false
+			v16First := true
+			for v16Name, v16Value := range in.Addition {
+				if v16First {
+					v16First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v8Name))
+				out.String(string(v16Name))
 				out.RawByte(':')
-				if m, ok := v8Value.(easyjson.Marshaler); ok {
+				if m, ok := v16Value.(easyjson.Marshaler); ok {
 					m.MarshalEasyJSON(out)
-				} else if m, ok := v8Value.(json.Marshaler); ok {
+				} else if m, ok := v16Value.(json.Marshaler); ok {
 					out.Raw(m.MarshalJSON())
 				} else {
-					out.Raw(json.Marshal(v8Value))
+					out.Raw(json.Marshal(v16Value))
 				}
 			}
 			out.RawByte('}')
@@ -1053,33 +1468,81 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang5(out *jwriter.Writer, in
 		}
 		out.Int64(int64(in.MiddleReqTime))
 	}
+	if len(in.Methods) != 0 {
+		const prefix string = ",\"methods\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v17First := true
+			for v17Name, v17Value := range in.Methods {
+				if v17First {
+					v17First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v17Name))
+				out.RawByte(':')
+				(v17Value).MarshalEasyJSON(out)
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Latency) != 0 {
+		const prefix string = ",\"latency\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v18First := true
+			for v18Name, v18Value := range in.Latency {
+				if v18First {
+					v18First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v18Name))
+				out.RawByte(':')
+				(v18Value).MarshalEasyJSON(out)
+			}
+			out.RawByte('}')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ServerStatus) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang5(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang8(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ServerStatus) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang5(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang8(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ServerStatus) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang5(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang8(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ServerStatus) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang5(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang8(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang6(in *jlexer.Lexer, out *ServerInfo) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang9(in *jlexer.Lexer, out *ServerInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1119,9 +1582,9 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang6(in *jlexer.Lexer, out *
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v9 string
-					v9 = string(in.String())
-					(out.Routes)[key] = v9
+					var v19 string
+					v19 = string(in.String())
+					(out.Routes)[key] = v19
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -1139,15 +1602,15 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang6(in *jlexer.Lexer, out *
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v10 interface{}
-					if m, ok := v10.(easyjson.Unmarshaler); ok {
+					var v20 interface{}
+					if m, ok := v20.(easyjson.Unmarshaler); ok {
 						m.UnmarshalEasyJSON(in)
-					} else if m, ok := v10.(json.Unmarshaler); ok {
+					} else if m, ok := v20.(json.Unmarshaler); ok {
 						_ = m.UnmarshalJSON(in.Raw())
 					} else {
-						v10 = in.Interface()
+						v20 = in.Interface()
 					}
-					(out.BaseTypes)[key] = v10
+					(out.BaseTypes)[key] = v20
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -1162,7 +1625,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang6(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang6(out *jwriter.Writer, in ServerInfo) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang9(out *jwriter.Writer, in ServerInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1191,16 +1654,16 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang6(out *jwriter.Writer, in
 		out.RawString(prefix)
 		{
 			out.RawByte('{')
-			v11First := true
-			for v11Name, v11Value := range in.Routes {
-				if v11First {
-					v11First = false
+			v21First := true
+			for v21Name, v21Value := range in.Routes {
+				if v21First {
+					v21First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v11Name))
+				out.String(string(v21Name))
 				out.RawByte(':')
-				out.String(string(v11Value))
+				out.String(string(v21Value))
 			}
 			out.RawByte('}')
 		}
@@ -1210,21 +1673,21 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang6(out *jwriter.Writer, in
 		out.RawString(prefix)
 		{
 			out.RawByte('{')
-			v12First := true
-			for v12Name, v12Value := range in.BaseTypes {
-				if v12First {
-					v12First = false
+			v22First := true
+			for v22Name, v22Value := range in.BaseTypes {
+				if v22First {
+					v22First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v12Name))
+				out.String(string(v22Name))
 				out.RawByte(':')
-				if m, ok := v12Value.(easyjson.Marshaler); ok {
+				if m, ok := v22Value.(easyjson.Marshaler); ok {
 					m.MarshalEasyJSON(out)
-				} else if m, ok := v12Value.(json.Marshaler); ok {
+				} else if m, ok := v22Value.(json.Marshaler); ok {
 					out.Raw(m.MarshalJSON())
 				} else {
-					out.Raw(json.Marshal(v12Value))
+					out.Raw(json.Marshal(v22Value))
 				}
 			}
 			out.RawByte('}')
@@ -1236,27 +1699,27 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang6(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v ServerInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang6(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ServerInfo) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang6(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ServerInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang6(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ServerInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang6(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang9(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang7(in *jlexer.Lexer, out *RoleSets) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang10(in *jlexer.Lexer, out *RoleSets) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1293,9 +1756,9 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang7(in *jlexer.Lexer, out *
 					out.Roles = (out.Roles)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v13 Role
-					(v13).UnmarshalEasyJSON(in)
-					out.Roles = append(out.Roles, v13)
+					var v23 Role
+					(v23).UnmarshalEasyJSON(in)
+					out.Roles = append(out.Roles, v23)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1310,7 +1773,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang7(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang7(out *jwriter.Writer, in RoleSets) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang10(out *jwriter.Writer, in RoleSets) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1330,11 +1793,11 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang7(out *jwriter.Writer, in
 		}
 		{
 			out.RawByte('[')
-			for v14, v15 := range in.Roles {
-				if v14 > 0 {
+			for v24, v25 := range in.Roles {
+				if v24 > 0 {
 					out.RawByte(',')
 				}
-				(v15).MarshalEasyJSON(out)
+				(v25).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1345,27 +1808,27 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang7(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v RoleSets) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang7(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang10(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RoleSets) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang7(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang10(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RoleSets) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang7(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang10(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RoleSets) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang7(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang10(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang8(in *jlexer.Lexer, out *Role) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang11(in *jlexer.Lexer, out *Role) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1416,7 +1879,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang8(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang8(out *jwriter.Writer, in Role) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang11(out *jwriter.Writer, in Role) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1471,27 +1934,27 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang8(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v Role) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang8(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang11(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Role) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang8(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang11(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Role) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang8(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang11(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Role) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang8(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang11(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang9(in *jlexer.Lexer, out *Group) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang12(in *jlexer.Lexer, out *Principal) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1511,27 +1974,598 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang9(in *jlexer.Lexer, out *
 		}
 		switch key {
 		case "id":
-			out.ID = uint64(in.Uint64())
-		case "name":
-			out.Name = string(in.String())
-		case "description":
-			out.Description = string(in.String())
-		case "ownerId":
-			out.OwnerID = uint32(in.Uint32())
-		case "comapnyId":
+			out.ID = uint32(in.Uint32())
+		case "email":
+			out.Email = string(in.String())
+		case "companyId":
 			out.CompanyID = uint32(in.Uint32())
-		case "modifiedBy":
-			out.ModifiedBy = uint32(in.Uint32())
-		case "addedDate":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.AddedDate).UnmarshalJSON(data))
-			}
-		case "modifDate":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.ModifiedDate).UnmarshalJSON(data))
+		case "roleIds":
+			if in.IsNull() {
+				in.Skip()
+				out.RoleIDs = nil
+			} else {
+				in.Delim('[')
+				if out.RoleIDs == nil {
+					if !in.IsDelim(']') {
+						out.RoleIDs = make([]uint32, 0, 16)
+					} else {
+						out.RoleIDs = []uint32{}
+					}
+				} else {
+					out.RoleIDs = (out.RoleIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v26 uint32
+					v26 = uint32(in.Uint32())
+					out.RoleIDs = append(out.RoleIDs, v26)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
-		case "logo":
-			out.Logo = string(in.String())
+		case "roleNames":
+			if in.IsNull() {
+				in.Skip()
+				out.RoleNames = nil
+			} else {
+				in.Delim('[')
+				if out.RoleNames == nil {
+					if !in.IsDelim(']') {
+						out.RoleNames = make([]string, 0, 4)
+					} else {
+						out.RoleNames = []string{}
+					}
+				} else {
+					out.RoleNames = (out.RoleNames)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v27 string
+					v27 = string(in.String())
+					out.RoleNames = append(out.RoleNames, v27)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sessionKey":
+			out.SessionKey = SessionKey(in.String())
+		case "expired":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ExpireDate).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang12(out *jwriter.Writer, in Principal) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint32(uint32(in.ID))
+	}
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	if in.CompanyID != 0 {
+		const prefix string = ",\"companyId\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.CompanyID))
+	}
+	if len(in.RoleIDs) != 0 {
+		const prefix string = ",\"roleIds\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v28, v29 := range in.RoleIDs {
+				if v28 > 0 {
+					out.RawByte(',')
+				}
+				out.Uint32(uint32(v29))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.RoleNames) != 0 {
+		const prefix string = ",\"roleNames\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v30, v31 := range in.RoleNames {
+				if v30 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v31))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.SessionKey != "" {
+		const prefix string = ",\"sessionKey\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionKey))
+	}
+	if true {
+		const prefix string = ",\"expired\":"
+		out.RawString(prefix)
+		out.Raw((in.ExpireDate).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Principal) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Principal) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Principal) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Principal) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang12(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang13(in *jlexer.Lexer, out *PageInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "total":
+			out.Total = int64(in.Int64())
+		case "limit":
+			out.Limit = int(in.Int())
+		case "offset":
+			out.Offset = int(in.Int())
+		case "nextCursor":
+			out.NextCursor = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang13(out *jwriter.Writer, in PageInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Total))
+	}
+	{
+		const prefix string = ",\"limit\":"
+		out.RawString(prefix)
+		out.Int(int(in.Limit))
+	}
+	if in.Offset != 0 {
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix)
+		out.Int(int(in.Offset))
+	}
+	if in.NextCursor != "" {
+		const prefix string = ",\"nextCursor\":"
+		out.RawString(prefix)
+		out.String(string(in.NextCursor))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PageInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PageInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PageInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PageInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang13(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang14(in *jlexer.Lexer, out *MethodStats) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "successCnt":
+			out.SuccessCnt = uint64(in.Uint64())
+		case "failedCnt":
+			out.FailedCnt = uint64(in.Uint64())
+		case "status2xx":
+			out.Status2xx = uint64(in.Uint64())
+		case "status3xx":
+			out.Status3xx = uint64(in.Uint64())
+		case "status4xx":
+			out.Status4xx = uint64(in.Uint64())
+		case "status5xx":
+			out.Status5xx = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang14(out *jwriter.Writer, in MethodStats) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"successCnt\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.SuccessCnt))
+	}
+	{
+		const prefix string = ",\"failedCnt\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.FailedCnt))
+	}
+	if in.Status2xx != 0 {
+		const prefix string = ",\"status2xx\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Status2xx))
+	}
+	if in.Status3xx != 0 {
+		const prefix string = ",\"status3xx\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Status3xx))
+	}
+	if in.Status4xx != 0 {
+		const prefix string = ",\"status4xx\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Status4xx))
+	}
+	if in.Status5xx != 0 {
+		const prefix string = ",\"status5xx\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Status5xx))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MethodStats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MethodStats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MethodStats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MethodStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang14(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang15(in *jlexer.Lexer, out *LatencyStats) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "count":
+			out.Count = uint64(in.Uint64())
+		case "p50":
+			out.P50 = time.Duration(in.Int64())
+		case "p95":
+			out.P95 = time.Duration(in.Int64())
+		case "p99":
+			out.P99 = time.Duration(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang15(out *jwriter.Writer, in LatencyStats) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.Count))
+	}
+	{
+		const prefix string = ",\"p50\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.P50))
+	}
+	{
+		const prefix string = ",\"p95\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.P95))
+	}
+	{
+		const prefix string = ",\"p99\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.P99))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LatencyStats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LatencyStats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LatencyStats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LatencyStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang15(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang16(in *jlexer.Lexer, out *GroupPage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]Group, 0, 0)
+					} else {
+						out.Items = []Group{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v32 Group
+					(v32).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v32)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "total":
+			out.Total = int64(in.Int64())
+		case "limit":
+			out.Limit = int(in.Int())
+		case "offset":
+			out.Offset = int(in.Int())
+		case "nextCursor":
+			out.NextCursor = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang16(out *jwriter.Writer, in GroupPage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix[1:])
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v33, v34 := range in.Items {
+				if v33 > 0 {
+					out.RawByte(',')
+				}
+				(v34).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Total))
+	}
+	{
+		const prefix string = ",\"limit\":"
+		out.RawString(prefix)
+		out.Int(int(in.Limit))
+	}
+	if in.Offset != 0 {
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix)
+		out.Int(int(in.Offset))
+	}
+	if in.NextCursor != "" {
+		const prefix string = ",\"nextCursor\":"
+		out.RawString(prefix)
+		out.String(string(in.NextCursor))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GroupPage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GroupPage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GroupPage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GroupPage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang16(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang17(in *jlexer.Lexer, out *Group) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "name":
+			out.Name = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "ownerId":
+			out.OwnerID = uint32(in.Uint32())
+		case "comapnyId":
+			out.CompanyID = uint32(in.Uint32())
+		case "modifiedBy":
+			out.ModifiedBy = uint32(in.Uint32())
+		case "addedDate":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.AddedDate).UnmarshalJSON(data))
+			}
+		case "modifDate":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ModifiedDate).UnmarshalJSON(data))
+			}
+		case "logo":
+			out.Logo = string(in.String())
 		case "status":
 			(out.Status).UnmarshalEasyJSON(in)
 		default:
@@ -1544,7 +2578,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang9(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang9(out *jwriter.Writer, in Group) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang17(out *jwriter.Writer, in Group) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1604,27 +2638,131 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang9(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v Group) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang9(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang17(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Group) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang9(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang17(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Group) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang9(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang17(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Group) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang9(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang17(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang18(in *jlexer.Lexer, out *FieldChange) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "field":
+			out.Field = string(in.String())
+		case "before":
+			if m, ok := out.Before.(easyjson.Unmarshaler); ok {
+				m.UnmarshalEasyJSON(in)
+			} else if m, ok := out.Before.(json.Unmarshaler); ok {
+				_ = m.UnmarshalJSON(in.Raw())
+			} else {
+				out.Before = in.Interface()
+			}
+		case "after":
+			if m, ok := out.After.(easyjson.Unmarshaler); ok {
+				m.UnmarshalEasyJSON(in)
+			} else if m, ok := out.After.(json.Unmarshaler); ok {
+				_ = m.UnmarshalJSON(in.Raw())
+			} else {
+				out.After = in.Interface()
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang18(out *jwriter.Writer, in FieldChange) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"field\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Field))
+	}
+	if in.Before != nil {
+		const prefix string = ",\"before\":"
+		out.RawString(prefix)
+		if m, ok := in.Before.(easyjson.Marshaler); ok {
+			m.MarshalEasyJSON(out)
+		} else if m, ok := in.Before.(json.Marshaler); ok {
+			out.Raw(m.MarshalJSON())
+		} else {
+			out.Raw(json.Marshal(in.Before))
+		}
+	}
+	if in.After != nil {
+		const prefix string = ",\"after\":"
+		out.RawString(prefix)
+		if m, ok := in.After.(easyjson.Marshaler); ok {
+			m.MarshalEasyJSON(out)
+		} else if m, ok := in.After.(json.Marshaler); ok {
+			out.Raw(m.MarshalJSON())
+		} else {
+			out.Raw(json.Marshal(in.After))
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FieldChange) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang18(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FieldChange) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang18(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FieldChange) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang18(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FieldChange) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang18(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang10(in *jlexer.Lexer, out *DBStats) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang19(in *jlexer.Lexer, out *DBStats) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1655,6 +2793,8 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang10(in *jlexer.Lexer, out
 			out.WaitCount = int64(in.Int64())
 		case "waitDuration":
 			out.WaitDuration = time.Duration(in.Int64())
+		case "acquireDuration":
+			out.AcquireDuration = time.Duration(in.Int64())
 		case "maxIdleClosed":
 			out.MaxIdleClosed = int64(in.Int64())
 		case "maxIdleTimeClosed":
@@ -1671,7 +2811,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang10(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang10(out *jwriter.Writer, in DBStats) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang19(out *jwriter.Writer, in DBStats) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1690,63 +2830,194 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang10(out *jwriter.Writer, i
 		out.RawString(prefix)
 		out.Int(int(in.InUse))
 	}
-	{
-		const prefix string = ",\"idleCon\":"
-		out.RawString(prefix)
-		out.Int(int(in.Idle))
+	{
+		const prefix string = ",\"idleCon\":"
+		out.RawString(prefix)
+		out.Int(int(in.Idle))
+	}
+	{
+		const prefix string = ",\"waitCon\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.WaitCount))
+	}
+	{
+		const prefix string = ",\"waitDuration\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.WaitDuration))
+	}
+	{
+		const prefix string = ",\"acquireDuration\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.AcquireDuration))
+	}
+	{
+		const prefix string = ",\"maxIdleClosed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxIdleClosed))
+	}
+	{
+		const prefix string = ",\"maxIdleTimeClosed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxIdleTimeClosed))
+	}
+	{
+		const prefix string = ",\"maxLifetimeClosed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.MaxLifetimeClosed))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DBStats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang19(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DBStats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang19(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DBStats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang19(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DBStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang19(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang20(in *jlexer.Lexer, out *CompanyPage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]Company, 0, 0)
+					} else {
+						out.Items = []Company{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v35 Company
+					(v35).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v35)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "total":
+			out.Total = int64(in.Int64())
+		case "limit":
+			out.Limit = int(in.Int())
+		case "offset":
+			out.Offset = int(in.Int())
+		case "nextCursor":
+			out.NextCursor = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang20(out *jwriter.Writer, in CompanyPage) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
-		const prefix string = ",\"waitCon\":"
-		out.RawString(prefix)
-		out.Int64(int64(in.WaitCount))
+		const prefix string = ",\"items\":"
+		out.RawString(prefix[1:])
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v36, v37 := range in.Items {
+				if v36 > 0 {
+					out.RawByte(',')
+				}
+				(v37).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	{
-		const prefix string = ",\"waitDuration\":"
+		const prefix string = ",\"total\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.WaitDuration))
+		out.Int64(int64(in.Total))
 	}
 	{
-		const prefix string = ",\"maxIdleClosed\":"
+		const prefix string = ",\"limit\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.MaxIdleClosed))
+		out.Int(int(in.Limit))
 	}
-	{
-		const prefix string = ",\"maxIdleTimeClosed\":"
+	if in.Offset != 0 {
+		const prefix string = ",\"offset\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.MaxIdleTimeClosed))
+		out.Int(int(in.Offset))
 	}
-	{
-		const prefix string = ",\"maxLifetimeClosed\":"
+	if in.NextCursor != "" {
+		const prefix string = ",\"nextCursor\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.MaxLifetimeClosed))
+		out.String(string(in.NextCursor))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v DBStats) MarshalJSON() ([]byte, error) {
+func (v CompanyPage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang10(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang20(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v DBStats) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang10(w, v)
+func (v CompanyPage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang20(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *DBStats) UnmarshalJSON(data []byte) error {
+func (v *CompanyPage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang10(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang20(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *DBStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang10(l, v)
+func (v *CompanyPage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang20(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang11(in *jlexer.Lexer, out *Company) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang21(in *jlexer.Lexer, out *Company) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1801,7 +3072,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang11(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang11(out *jwriter.Writer, in Company) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang21(out *jwriter.Writer, in Company) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1866,27 +3137,27 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang11(out *jwriter.Writer, i
 // MarshalJSON supports json.Marshaler interface
 func (v Company) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang11(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang21(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Company) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang11(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang21(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Company) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang11(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang21(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Company) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang11(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang21(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang12(in *jlexer.Lexer, out *Comment) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang22(in *jlexer.Lexer, out *Comment) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1935,7 +3206,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang12(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang12(out *jwriter.Writer, in Comment) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang22(out *jwriter.Writer, in Comment) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2021,27 +3292,306 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang12(out *jwriter.Writer, i
 // MarshalJSON supports json.Marshaler interface
 func (v Comment) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang12(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang22(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Comment) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang12(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang22(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Comment) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang12(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang22(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Comment) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang12(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang22(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang23(in *jlexer.Lexer, out *AuditRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "entityType":
+			out.EntityType = string(in.String())
+		case "entityId":
+			out.EntityID = uint64(in.Uint64())
+		case "action":
+			out.Action = uint32(in.Uint32())
+		case "actorId":
+			out.ActorID = uint32(in.Uint32())
+		case "requestId":
+			out.RequestID = string(in.String())
+		case "changes":
+			if in.IsNull() {
+				in.Skip()
+				out.Changes = nil
+			} else {
+				in.Delim('[')
+				if out.Changes == nil {
+					if !in.IsDelim(']') {
+						out.Changes = make([]FieldChange, 0, 1)
+					} else {
+						out.Changes = []FieldChange{}
+					}
+				} else {
+					out.Changes = (out.Changes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v38 FieldChange
+					(v38).UnmarshalEasyJSON(in)
+					out.Changes = append(out.Changes, v38)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "addedDate":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.AddedDate).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang23(out *jwriter.Writer, in AuditRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != 0 {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"entityType\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.EntityType))
+	}
+	{
+		const prefix string = ",\"entityId\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.EntityID))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.Action))
+	}
+	if in.ActorID != 0 {
+		const prefix string = ",\"actorId\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.ActorID))
+	}
+	if in.RequestID != "" {
+		const prefix string = ",\"requestId\":"
+		out.RawString(prefix)
+		out.String(string(in.RequestID))
+	}
+	if len(in.Changes) != 0 {
+		const prefix string = ",\"changes\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v39, v40 := range in.Changes {
+				if v39 > 0 {
+					out.RawByte(',')
+				}
+				(v40).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"addedDate\":"
+		out.RawString(prefix)
+		out.Raw((in.AddedDate).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang23(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang24(in *jlexer.Lexer, out *AuditPage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]AuditRecord, 0, 0)
+					} else {
+						out.Items = []AuditRecord{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v41 AuditRecord
+					(v41).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v41)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "total":
+			out.Total = int64(in.Int64())
+		case "limit":
+			out.Limit = int(in.Int())
+		case "offset":
+			out.Offset = int(in.Int())
+		case "nextCursor":
+			out.NextCursor = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang24(out *jwriter.Writer, in AuditPage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix[1:])
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v42, v43 := range in.Items {
+				if v42 > 0 {
+					out.RawByte(',')
+				}
+				(v43).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Total))
+	}
+	{
+		const prefix string = ",\"limit\":"
+		out.RawString(prefix)
+		out.Int(int(in.Limit))
+	}
+	if in.Offset != 0 {
+		const prefix string = ",\"offset\":"
+		out.RawString(prefix)
+		out.Int(int(in.Offset))
+	}
+	if in.NextCursor != "" {
+		const prefix string = ",\"nextCursor\":"
+		out.RawString(prefix)
+		out.String(string(in.NextCursor))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditPage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditPage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang24(w, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang13(in *jlexer.Lexer, out *Address) {
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditPage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditPage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang24(l, v)
+}
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang25(in *jlexer.Lexer, out *Address) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2110,7 +3660,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang13(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang13(out *jwriter.Writer, in Address) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang25(out *jwriter.Writer, in Address) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2210,27 +3760,27 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang13(out *jwriter.Writer, i
 // MarshalJSON supports json.Marshaler interface
 func (v Address) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang13(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang25(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Address) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang13(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang25(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Address) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang13(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang25(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Address) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang13(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang25(l, v)
 }
-func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang14(in *jlexer.Lexer, out *APIToken) {
+func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang26(in *jlexer.Lexer, out *APIToken) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2273,9 +3823,9 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang14(in *jlexer.Lexer, out
 					out.Roles = (out.Roles)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v16 Role
-					(v16).UnmarshalEasyJSON(in)
-					out.Roles = append(out.Roles, v16)
+					var v44 Role
+					(v44).UnmarshalEasyJSON(in)
+					out.Roles = append(out.Roles, v44)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2298,7 +3848,7 @@ func easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang14(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang14(out *jwriter.Writer, in APIToken) {
+func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang26(out *jwriter.Writer, in APIToken) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2327,11 +3877,11 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang14(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v17, v18 := range in.Roles {
-				if v17 > 0 {
+			for v45, v46 := range in.Roles {
+				if v45 > 0 {
 					out.RawByte(',')
 				}
-				(v18).MarshalEasyJSON(out)
+				(v46).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2352,23 +3902,23 @@ func easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang14(out *jwriter.Writer, i
 // MarshalJSON supports json.Marshaler interface
 func (v APIToken) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang14(&w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang26(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v APIToken) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang14(w, v)
+	easyjson56de76c1EncodeGithubComBlabuEgeonLibGolang26(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *APIToken) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang14(&r, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang26(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *APIToken) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang14(l, v)
+	easyjson56de76c1DecodeGithubComBlabuEgeonLibGolang26(l, v)
 }
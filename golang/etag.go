@@ -0,0 +1,238 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	retry "github.com/hashicorp/go-retryablehttp"
+)
+
+// Заголовки условных запросов
+const (
+	ETagHeaderKey        = "ETag"
+	IfMatchHeaderKey     = "If-Match"
+	IfNoneMatchHeaderKey = "If-None-Match"
+)
+
+// Versioned - DTO с версией для оптимистичной блокировки. Версия меняется при каждом изменении записи
+type Versioned interface {
+	Version() time.Time
+}
+
+func version(modified, added time.Time) time.Time {
+	if modified.IsZero() {
+		return added
+	}
+	return modified
+}
+
+func (c Company) Version() time.Time     { return version(c.ModifiedDate, c.AddedDate) }
+func (g Group) Version() time.Time       { return version(g.ModifiedDate, g.AddedDate) }
+func (a Address) Version() time.Time     { return version(a.ModifiedDate, a.AddedDate) }
+func (u User) Version() time.Time        { return version(u.ModifiedDate, u.AddedDate) }
+func (p UserProfile) Version() time.Time { return version(p.ModifiedDate, p.AddedDate) }
+func (c Comment) Version() time.Time     { return version(c.ModifiedDate, c.AddedDate) }
+func (r Role) Version() time.Time        { return version(r.ModifiedDate, r.AddedDate) }
+func (g UsersGroup) Version() time.Time  { return version(g.ModifiedDate, g.AddedDate) }
+
+// VersionETag - strong ETag of the version. Empty for zero version
+func VersionETag(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return `"` + strconv.FormatInt(t.UnixNano(), 36) + `"`
+}
+
+// ParseVersionETag - version of the ETag made by VersionETag
+func ParseVersionETag(etag string) (time.Time, bool) {
+	if len(etag) < 3 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return time.Time{}, false
+	}
+	n, err := strconv.ParseInt(etag[1:len(etag)-1], 36, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, n), true
+}
+
+type expectedVersionKey struct{}
+
+// WithExpectedVersion - context with version of the record that request expects to change
+func WithExpectedVersion(ctx context.Context, v time.Time) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, v)
+}
+
+// ExpectedVersion - version checked by If-Match (IfMatchMiddleware puts it for modifying requests).
+// Store should change the record only if it has this version (UPDATE ... WHERE modif_date = $v)
+// and return VersionConflict() if no rows were changed, because the record could be changed after the check
+func ExpectedVersion(ctx context.Context) (time.Time, bool) {
+	v, ok := ctx.Value(expectedVersionKey{}).(time.Time)
+	return v, ok
+}
+
+// VersionConflict - error of the change of other version of the record (BadUpdateAttempt, http 412)
+func VersionConflict() error {
+	return EgeonError{Code: BadUpdateAttempt, Description: Errors["versionConflict"].Error()}
+}
+
+// ETag - ETag of the DTO version
+func ETag(v Versioned) string {
+	return VersionETag(v.Version())
+}
+
+// MatchETag - check If-Match (If-None-Match) header value against current ETag by strong comparison.
+// "*" matches any existing version
+func MatchETag(header, etag string) bool {
+	if len(etag) == 0 {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ETagFunc - current ETag of the resource of the request (empty if resource does not exist)
+type ETagFunc func(r *http.Request) (string, error)
+
+// GinETagFunc - the same as ETagFunc for gin handlers (path parameters are available in gin context)
+type GinETagFunc func(c *gin.Context) (string, error)
+
+// isModifying - method changes the resource
+func isModifying(method string) bool {
+	return method == http.MethodPut || method == http.MethodPatch || method == http.MethodDelete
+}
+
+// checkPrecondition - check conditional headers of the request (RFC 7232: If-Match, then If-None-Match).
+// Return http status and error if request must be stopped (304 without error for not modified GET and HEAD)
+func checkPrecondition(r *http.Request, etag string, required bool) (int, error) {
+	ifMatch := r.Header.Get(IfMatchHeaderKey)
+	if isModifying(r.Method) && len(ifMatch) != 0 && !MatchETag(ifMatch, etag) {
+		return http.StatusPreconditionFailed, VersionConflict()
+	}
+	if inm := r.Header.Get(IfNoneMatchHeaderKey); len(inm) != 0 && MatchETag(inm, etag) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			return http.StatusNotModified, nil
+		}
+		return http.StatusPreconditionFailed, VersionConflict()
+	}
+	if isModifying(r.Method) && len(ifMatch) == 0 && required {
+		return http.StatusPreconditionRequired, EgeonError{Code: BadUpdateAttempt, Description: Errors["versionRequired"].Error()}
+	}
+	return 0, nil
+}
+
+// expectVersion - request with the checked version in the context (modifying request with matched If-Match)
+func expectVersion(r *http.Request, etag string) *http.Request {
+	if !isModifying(r.Method) || len(r.Header.Get(IfMatchHeaderKey)) == 0 {
+		return r
+	}
+	if v, ok := ParseVersionETag(etag); ok {
+		return r.WithContext(WithExpectedVersion(r.Context(), v))
+	}
+	return r
+}
+
+// errorStatus - http status and body for error of the resource loader.
+// Other errors are logged and answered with common text, so details of the storage are not sent to the client
+func errorStatus(ctx context.Context, err error) (int, EgeonError) {
+	var e EgeonError
+	if errors.As(err, &e) {
+		switch e.Code {
+		case NotFindItemError:
+			return http.StatusNotFound, e
+		case BadUpdateAttempt:
+			return http.StatusPreconditionFailed, e
+		}
+		return http.StatusInternalServerError, e
+	}
+	GetLogger().Error(ctx, "resource loading failed", "error", err)
+	return http.StatusInternalServerError, EgeonError{Code: InternalError, Description: Errors["internal"].Error()}
+}
+
+// IfMatchMiddleware - optimistic concurrency control for gin.
+// GET and HEAD get ETag header of the current version (and 304 for matched If-None-Match, other methods get 412),
+// PUT, PATCH and DELETE with If-Match of other version are rejected with 412 and BadUpdateAttempt error,
+// with matched If-Match the version is passed to the handler by context (see ExpectedVersion).
+// required - reject modifying requests without If-Match with 428
+func IfMatchMiddleware(current GinETagFunc, required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		etag, err := current(c)
+		if err != nil {
+			status, e := errorStatus(c.Request.Context(), err)
+			c.AbortWithStatusJSON(status, e)
+			return
+		}
+		status, err := checkPrecondition(c.Request, etag, required)
+		if status == http.StatusNotModified {
+			c.Header(ETagHeaderKey, etag)
+			c.AbortWithStatus(status)
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(status, err)
+			return
+		}
+		if len(etag) != 0 && !isModifying(c.Request.Method) {
+			c.Header(ETagHeaderKey, etag)
+		}
+		c.Request = expectVersion(c.Request, etag)
+		c.Next()
+	}
+}
+
+// IfMatchHTTPMiddleware - the same as IfMatchMiddleware for net/http
+func IfMatchHTTPMiddleware(current ETagFunc, required bool, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag, err := current(r)
+		if err != nil {
+			status, e := errorStatus(r.Context(), err)
+			writeJSON(w, status, e)
+			return
+		}
+		status, err := checkPrecondition(r, etag, required)
+		if status == http.StatusNotModified {
+			w.Header().Set(ETagHeaderKey, etag)
+			w.WriteHeader(status)
+			return
+		}
+		if err != nil {
+			writeJSON(w, status, err)
+			return
+		}
+		if len(etag) != 0 && !isModifying(r.Method) {
+			w.Header().Set(ETagHeaderKey, etag)
+		}
+		handler.ServeHTTP(w, expectVersion(r, etag))
+	})
+}
+
+// SetETag - set ETag header of the DTO version to the response (use it in handlers without IfMatchMiddleware)
+func SetETag(w http.ResponseWriter, v Versioned) {
+	if etag := ETag(v); len(etag) != 0 {
+		w.Header().Set(ETagHeaderKey, etag)
+	}
+}
+
+// IfMatch - request editor for DoRequest that send If-Match header with etag
+func IfMatch(etag string) RequestEditorFn {
+	return func(ctx context.Context, req *retry.Request) error {
+		if len(etag) != 0 {
+			req.Header.Set(IfMatchHeaderKey, etag)
+		}
+		return nil
+	}
+}
+
+// IfMatchVersion - request editor for DoRequest that send If-Match header with version of the DTO being changed
+func IfMatchVersion(v Versioned) RequestEditorFn {
+	return IfMatch(ETag(v))
+}
@@ -0,0 +1,91 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseVersionETag(t *testing.T) {
+	v := time.Date(2026, 5, 1, 10, 20, 30, 123456000, time.UTC)
+	got, ok := ParseVersionETag(VersionETag(v))
+	if !ok || !got.Equal(v) {
+		t.Fatalf("%v %v", got, ok)
+	}
+	for _, bad := range []string{"", `""`, "abc", `"zz!"`, `W/"abc"`} {
+		if _, ok := ParseVersionETag(bad); ok {
+			t.Errorf("%q parsed", bad)
+		}
+	}
+}
+
+func TestIfMatchHTTPMiddleware(t *testing.T) {
+	v := time.Date(2026, 5, 1, 10, 20, 30, 0, time.UTC)
+	etag := VersionETag(v)
+	var expected time.Time
+	var hasExpected bool
+	h := IfMatchHTTPMiddleware(func(r *http.Request) (string, error) { return etag, nil }, true,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			expected, hasExpected = ExpectedVersion(r.Context())
+		}))
+	cases := []struct {
+		method, ifMatch, ifNoneMatch string
+		status                       int
+		expected                     bool
+	}{
+		{http.MethodGet, "", "", http.StatusOK, false},
+		{http.MethodGet, "", etag, http.StatusNotModified, false},
+		{http.MethodHead, "", "*", http.StatusNotModified, false},
+		{http.MethodGet, "", `"other"`, http.StatusOK, false},
+		{http.MethodPost, "", etag, http.StatusPreconditionFailed, false},
+		{http.MethodPut, "", "*", http.StatusPreconditionFailed, false},
+		{http.MethodDelete, etag, etag, http.StatusPreconditionFailed, false},
+		{http.MethodPatch, "", "", http.StatusPreconditionRequired, false},
+		{http.MethodPatch, `"other"`, "", http.StatusPreconditionFailed, false},
+		{http.MethodPatch, etag, "", http.StatusOK, true},
+		{http.MethodPut, "*", `"other"`, http.StatusOK, true},
+	}
+	for _, c := range cases {
+		hasExpected = false
+		r := httptest.NewRequest(c.method, "/", nil)
+		if len(c.ifMatch) != 0 {
+			r.Header.Set(IfMatchHeaderKey, c.ifMatch)
+		}
+		if len(c.ifNoneMatch) != 0 {
+			r.Header.Set(IfNoneMatchHeaderKey, c.ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != c.status || hasExpected != c.expected || c.expected && !expected.Equal(v) {
+			t.Errorf("%s If-Match %q If-None-Match %q: %d, expected version %v %v", c.method, c.ifMatch, c.ifNoneMatch, w.Code, expected, hasExpected)
+		}
+		if etagHeader := w.Header().Get(ETagHeaderKey); (c.method == http.MethodGet || c.method == http.MethodHead) && etagHeader != etag {
+			t.Errorf("%s: ETag %q", c.method, etagHeader)
+		}
+	}
+}
+
+func TestIfMatchLoaderError(t *testing.T) {
+	h := IfMatchHTTPMiddleware(func(r *http.Request) (string, error) {
+		return "", EgeonError{Code: NotFindItemError, Description: Errors["notFindRecord"].Error()}
+	}, false, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status %d", w.Code)
+	}
+	if status, _ := errorStatus(context.Background(), VersionConflict()); status != http.StatusPreconditionFailed {
+		t.Fatalf("version conflict status %d", status)
+	}
+	// ошибка хранилища не передается клиенту
+	status, e := errorStatus(context.Background(), errors.New("pq: relation users does not exist"))
+	if status != http.StatusInternalServerError || e.Code != InternalError || e.Description != Errors["internal"].Error() {
+		t.Fatalf("internal error %d %+v", status, e)
+	}
+	if !errors.Is(VersionConflict(), Errors["versionConflict"]) {
+		t.Fatal("VersionConflict does not match Errors")
+	}
+}
@@ -0,0 +1,837 @@
+package golang
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// FilterOp - оператор сравнения в выражении фильтра
+type FilterOp string
+
+const (
+	FilterEq    FilterOp = "eq"
+	FilterNe    FilterOp = "ne"
+	FilterGt    FilterOp = "gt"
+	FilterGe    FilterOp = "ge"
+	FilterLt    FilterOp = "lt"
+	FilterLe    FilterOp = "le"
+	FilterLike  FilterOp = "like"  // шаблон: * - любая строка, ? - любой символ
+	FilterILike FilterOp = "ilike" // like без учета регистра
+	FilterIn    FilterOp = "in"    // field in (1, 2, 3)
+)
+
+// Ограничения выражения фильтра. Каждое значение - параметр SQL запроса (PostgreSQL допускает не более 65535)
+var (
+	MaxFilterDepth  = 16   // вложенность скобок и not
+	MaxFilterValues = 1000 // значений во всех сравнениях и списках in
+)
+
+// FilterNode - узел дерева выражения фильтра
+type FilterNode interface {
+	filterNode()
+}
+
+// FilterAnd - все условия должны выполняться
+type FilterAnd []FilterNode
+
+// FilterOr - хотя бы одно условие должно выполняться
+type FilterOr []FilterNode
+
+// FilterNot - отрицание условия
+type FilterNot struct {
+	Expr FilterNode
+}
+
+// FilterCompare - сравнение поля с литералом. После FilterSchema.Validate значения приведены к типу поля
+// (string, int64, uint64, float64, bool, time.Time или nil для null)
+type FilterCompare struct {
+	Field  string
+	Op     FilterOp
+	Values []interface{} // одно значение, для FilterIn - список
+}
+
+func (FilterAnd) filterNode()     {}
+func (FilterOr) filterNode()      {}
+func (FilterNot) filterNode()     {}
+func (FilterCompare) filterNode() {}
+
+// filterLiteral - литерал до приведения к типу поля
+type filterLiteral struct {
+	text   string
+	quoted bool
+}
+
+func filterError(format string, args ...interface{}) error {
+	return NewValidateError(FieldError{Field: FilterQueryKey, Message: fmt.Sprintf(format, args...)})
+}
+
+// filterToken - лексема выражения
+type filterToken struct {
+	text   string
+	quoted bool // строка в кавычках
+	pos    int
+}
+
+func tokenizeFilter(s string) ([]filterToken, error) {
+	var res []filterToken
+	rs := []rune(s)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',':
+			res = append(res, filterToken{text: string(r), pos: i})
+			i++
+		case r == '"' || r == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(rs) && rs[j] != r; j++ {
+				if rs[j] == '\\' && j+1 < len(rs) {
+					j++
+				}
+				b.WriteRune(rs[j])
+			}
+			if j == len(rs) {
+				return nil, filterError("не закрито лапки в позиції %d", i)
+			}
+			res = append(res, filterToken{text: b.String(), quoted: true, pos: i})
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(rs) && !unicode.IsSpace(rs[j]) && !strings.ContainsRune("(),\"'", rs[j]); j++ {
+			}
+			res = append(res, filterToken{text: string(rs[i:j]), pos: i})
+			i = j
+		}
+	}
+	return res, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+	depth  int
+	values int
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos], true
+	}
+	return filterToken{}, false
+}
+
+func (p *filterParser) next() (filterToken, error) {
+	t, ok := p.peek()
+	if !ok {
+		return t, filterError("неочікуваний кінець виразу")
+	}
+	p.pos++
+	return t, nil
+}
+
+// keyword - token is unquoted keyword (case insensitive)
+func (p *filterParser) keyword(kw string) bool {
+	if t, ok := p.peek(); ok && !t.quoted && strings.EqualFold(t.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) expect(s string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if t.quoted || t.text != s {
+		return filterError("очікується %q в позиції %d", s, t.pos)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (FilterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	res := FilterOr{left}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, right)
+	}
+	if len(res) == 1 {
+		return left, nil
+	}
+	return res, nil
+}
+
+func (p *filterParser) parseAnd() (FilterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	res := FilterAnd{left}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, right)
+	}
+	if len(res) == 1 {
+		return left, nil
+	}
+	return res, nil
+}
+
+func (p *filterParser) parseUnary() (FilterNode, error) {
+	if p.depth++; p.depth > MaxFilterDepth {
+		return nil, filterError("вкладеність виразу більше %d", MaxFilterDepth)
+	}
+	defer func() { p.depth-- }()
+	if p.keyword("not") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return FilterNot{Expr: expr}, nil
+	}
+	if t, ok := p.peek(); ok && !t.quoted && t.text == "(" {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expect(")")
+	}
+	return p.parseCompare()
+}
+
+func (p *filterParser) literal() (filterLiteral, error) {
+	t, err := p.next()
+	if err != nil {
+		return filterLiteral{}, err
+	}
+	if !t.quoted && (t.text == "(" || t.text == ")" || t.text == ",") {
+		return filterLiteral{}, filterError("очікується значення в позиції %d", t.pos)
+	}
+	if p.values++; p.values > MaxFilterValues {
+		return filterLiteral{}, filterError("більше %d значень у виразі", MaxFilterValues)
+	}
+	return filterLiteral{text: t.text, quoted: t.quoted}, nil
+}
+
+func (p *filterParser) parseCompare() (FilterNode, error) {
+	field, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if field.quoted || strings.ContainsAny(field.text, "(),") {
+		return nil, filterError("очікується назва поля в позиції %d", field.pos)
+	}
+	opTok, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op := FilterOp(strings.ToLower(opTok.text))
+	cmp := FilterCompare{Field: field.text, Op: op}
+	switch op {
+	case FilterEq, FilterNe, FilterGt, FilterGe, FilterLt, FilterLe, FilterLike, FilterILike:
+		v, err := p.literal()
+		if err != nil {
+			return nil, err
+		}
+		cmp.Values = []interface{}{v}
+	case FilterIn:
+		if err = p.expect("("); err != nil {
+			return nil, err
+		}
+		for {
+			v, err := p.literal()
+			if err != nil {
+				return nil, err
+			}
+			cmp.Values = append(cmp.Values, v)
+			if p.keyword(")") {
+				break
+			}
+			if err = p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, filterError("невідомий оператор %q в позиції %d", opTok.text, opTok.pos)
+	}
+	return cmp, nil
+}
+
+// ParseFilter - parse filter expression, for example:
+//
+//	status.id eq 2 and addedDate ge 2026-01-01 and (name like "Київ*" or code in (1, 2))
+//
+// Values are not typed until FilterSchema.Validate
+func ParseFilter(expr string) (FilterNode, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	p := filterParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := p.peek(); ok {
+		return nil, filterError("зайвий текст %q в позиції %d", t.text, t.pos)
+	}
+	return node, nil
+}
+
+// filterKind - тип поля для фильтрации
+type filterKind uint8
+
+const (
+	kindString filterKind = iota
+	kindInt
+	kindUint
+	kindFloat
+	kindBool
+	kindTime
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type filterField struct {
+	index    []int
+	kind     filterKind
+	column   string
+	nullable bool // колонка может быть NULL, в DTO NULL - нулевое значение поля
+}
+
+// FilterSchema - поля DTO, по которым разрешена фильтрация (имена по json тегам) и колонки SQL.
+// Каноническая семантика - SQL: сравнение с NULL (кроме eq null и ne null) не выполняется, и not его не меняет,
+// поэтому "ne" не выбирает строки с NULL. Match повторяет ее для полей SetNullable, у остальных полей NULL не бывает
+type FilterSchema struct {
+	typ    reflect.Type
+	fields map[string]filterField
+}
+
+// snakeColumn - default column of the field path: status.id -> status_id, addedDate -> added_date
+func snakeColumn(path string) string {
+	var b strings.Builder
+	for i, r := range path {
+		switch {
+		case r == '.':
+			b.WriteByte('_')
+		case unicode.IsUpper(r):
+			if i != 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// NewFilterSchema - schema of the sample DTO with allowed field paths (address.city, status.id).
+// Column of the field is snake case of the path, it can be changed by SetColumn. Unknown path is a programming error (panic)
+func NewFilterSchema(sample interface{}, fields ...string) *FilterSchema {
+	t := reflect.Indirect(reflect.ValueOf(sample)).Type()
+	s := &FilterSchema{typ: t, fields: make(map[string]filterField, len(fields))}
+	for _, path := range fields {
+		cur := t
+		var index []int
+		for _, name := range strings.Split(path, ".") {
+			if cur.Kind() != reflect.Struct || cur == timeType {
+				panic(fmt.Sprintf("filter field %s is not found in %s", path, t))
+			}
+			i, ok := fieldIndexes(cur)[name]
+			if !ok {
+				panic(fmt.Sprintf("filter field %s is not found in %s", path, t))
+			}
+			index = append(index, i)
+			cur = cur.Field(i).Type
+		}
+		f := filterField{index: index, column: snakeColumn(path)}
+		switch {
+		case cur == timeType:
+			f.kind = kindTime
+		case cur.Kind() == reflect.String:
+			f.kind = kindString
+		case cur.Kind() == reflect.Bool:
+			f.kind = kindBool
+		case cur.Kind() >= reflect.Int && cur.Kind() <= reflect.Int64:
+			f.kind = kindInt
+		case cur.Kind() >= reflect.Uint && cur.Kind() <= reflect.Uint64:
+			f.kind = kindUint
+		case cur.Kind() == reflect.Float32 || cur.Kind() == reflect.Float64:
+			f.kind = kindFloat
+		default:
+			panic(fmt.Sprintf("filter field %s of %s has unsupported type %s", path, t, cur))
+		}
+		s.fields[path] = f
+	}
+	return s
+}
+
+// SetColumn - SQL column (or expression) of the field
+func (s *FilterSchema) SetColumn(field, column string) *FilterSchema {
+	f, ok := s.fields[field]
+	if !ok {
+		panic(fmt.Sprintf("filter field %s is not in schema", field))
+	}
+	f.column = column
+	s.fields[field] = f
+	return s
+}
+
+// SetNullable - column of the fields can be NULL, it is read into DTO as zero value of the field.
+// Only nullable fields can be compared with null
+func (s *FilterSchema) SetNullable(fields ...string) *FilterSchema {
+	for _, field := range fields {
+		f, ok := s.fields[field]
+		if !ok {
+			panic(fmt.Sprintf("filter field %s is not in schema", field))
+		}
+		f.nullable = true
+		s.fields[field] = f
+	}
+	return s
+}
+
+// typed - convert literal to the type of the field
+func (f filterField) typed(name string, lit filterLiteral) (interface{}, error) {
+	if !lit.quoted && strings.EqualFold(lit.text, "null") {
+		return nil, nil
+	}
+	bad := func() error {
+		return filterError("значення %q не відповідає типу поля %s", lit.text, name)
+	}
+	switch f.kind {
+	case kindString:
+		return lit.text, nil
+	case kindInt:
+		v, err := strconv.ParseInt(lit.text, 10, 64)
+		if err != nil {
+			return nil, bad()
+		}
+		return v, nil
+	case kindUint:
+		v, err := strconv.ParseUint(lit.text, 10, 64)
+		if err != nil {
+			return nil, bad()
+		}
+		return v, nil
+	case kindFloat:
+		v, err := strconv.ParseFloat(lit.text, 64)
+		if err != nil {
+			return nil, bad()
+		}
+		return v, nil
+	case kindBool:
+		v, err := strconv.ParseBool(lit.text)
+		if err != nil {
+			return nil, bad()
+		}
+		return v, nil
+	case kindTime:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+			if v, err := time.Parse(layout, lit.text); err == nil {
+				return v, nil
+			}
+		}
+		return nil, bad()
+	}
+	return nil, bad()
+}
+
+// Validate - check fields and operators of the expression and convert values to types of the fields.
+// Return new tree with typed values
+func (s *FilterSchema) Validate(node FilterNode) (FilterNode, error) {
+	switch n := node.(type) {
+	case nil:
+		return nil, nil
+	case FilterAnd:
+		res := make(FilterAnd, len(n))
+		for i := range n {
+			v, err := s.Validate(n[i])
+			if err != nil {
+				return nil, err
+			}
+			res[i] = v
+		}
+		return res, nil
+	case FilterOr:
+		res := make(FilterOr, len(n))
+		for i := range n {
+			v, err := s.Validate(n[i])
+			if err != nil {
+				return nil, err
+			}
+			res[i] = v
+		}
+		return res, nil
+	case FilterNot:
+		v, err := s.Validate(n.Expr)
+		return FilterNot{Expr: v}, err
+	case FilterCompare:
+		f, ok := s.fields[n.Field]
+		if !ok {
+			return nil, filterError("фільтрація за полем %s не підтримується", n.Field)
+		}
+		if (n.Op == FilterLike || n.Op == FilterILike) && f.kind != kindString {
+			return nil, filterError("оператор %s можна застосувати тільки до рядків", n.Op)
+		}
+		if f.kind == kindBool && n.Op != FilterEq && n.Op != FilterNe && n.Op != FilterIn {
+			return nil, filterError("оператор %s не можна застосувати до поля %s", n.Op, n.Field)
+		}
+		res := FilterCompare{Field: n.Field, Op: n.Op, Values: make([]interface{}, len(n.Values))}
+		for i, v := range n.Values {
+			if typed, ok := v.(filterLiteral); ok {
+				var err error
+				if v, err = f.typed(n.Field, typed); err != nil {
+					return nil, err
+				}
+			}
+			if v == nil && n.Op != FilterEq && n.Op != FilterNe {
+				return nil, filterError("null можна порівнювати тільки операторами eq та ne")
+			}
+			if v == nil && !f.nullable {
+				return nil, filterError("поле %s не може бути null", n.Field)
+			}
+			res.Values[i] = v
+		}
+		return res, nil
+	}
+	return nil, filterError("невідомий вузол виразу")
+}
+
+// Parse - parse and validate expressions joined by and (for example PageRequest.Filters). Nil - no filter
+func (s *FilterSchema) Parse(exprs ...string) (FilterNode, error) {
+	var and FilterAnd
+	for _, e := range exprs {
+		node, err := ParseFilter(e)
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			and = append(and, node)
+		}
+	}
+	if filterValues(and) > MaxFilterValues {
+		return nil, filterError("більше %d значень у виразі", MaxFilterValues)
+	}
+	switch len(and) {
+	case 0:
+		return nil, nil
+	case 1:
+		return s.Validate(and[0])
+	}
+	return s.Validate(and)
+}
+
+// filterValues - count of values (SQL parameters) in the expression
+func filterValues(node FilterNode) int {
+	switch n := node.(type) {
+	case FilterAnd:
+		cnt := 0
+		for _, c := range n {
+			cnt += filterValues(c)
+		}
+		return cnt
+	case FilterOr:
+		return filterValues(FilterAnd(n))
+	case FilterNot:
+		return filterValues(n.Expr)
+	case FilterCompare:
+		return len(n.Values)
+	}
+	return 0
+}
+
+// likePattern - filter pattern (*, ?) to SQL LIKE pattern with escaped % and _
+func likePattern(p string) string {
+	var b strings.Builder
+	for _, r := range p {
+		switch r {
+		case '%', '_', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '*':
+			b.WriteByte('%')
+		case '?':
+			b.WriteByte('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+var sqlOps = map[FilterOp]string{
+	FilterEq: "=", FilterNe: "<>", FilterGt: ">", FilterGe: ">=", FilterLt: "<", FilterLe: "<=",
+	FilterLike: "LIKE", FilterILike: "ILIKE",
+}
+
+// SQL - PostgreSQL WHERE condition with $n placeholders starting from firstParam and its arguments.
+// Node must be validated by this schema. Nil node gives "TRUE"
+func (s *FilterSchema) SQL(node FilterNode, firstParam int) (string, []interface{}) {
+	var args []interface{}
+	var b strings.Builder
+	s.writeSQL(&b, node, firstParam, &args)
+	return b.String(), args
+}
+
+func (s *FilterSchema) writeSQL(b *strings.Builder, node FilterNode, firstParam int, args *[]interface{}) {
+	param := func(v interface{}) string {
+		*args = append(*args, v)
+		return "$" + strconv.Itoa(firstParam+len(*args)-1)
+	}
+	join := func(nodes []FilterNode, sep string) {
+		b.WriteByte('(')
+		for i, n := range nodes {
+			if i != 0 {
+				b.WriteString(sep)
+			}
+			s.writeSQL(b, n, firstParam, args)
+		}
+		b.WriteByte(')')
+	}
+	switch n := node.(type) {
+	case nil:
+		b.WriteString("TRUE")
+	case FilterAnd:
+		join(n, " AND ")
+	case FilterOr:
+		join(n, " OR ")
+	case FilterNot:
+		b.WriteString("NOT ")
+		join([]FilterNode{n.Expr}, "")
+	case FilterCompare:
+		col := s.fields[n.Field].column
+		switch {
+		case n.Op == FilterIn:
+			b.WriteString(col + " IN (")
+			for i, v := range n.Values {
+				if i != 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(param(v))
+			}
+			b.WriteByte(')')
+		case n.Values[0] == nil && n.Op == FilterEq:
+			b.WriteString(col + " IS NULL")
+		case n.Values[0] == nil:
+			b.WriteString(col + " IS NOT NULL")
+		case n.Op == FilterLike || n.Op == FilterILike:
+			b.WriteString(col + " " + sqlOps[n.Op] + " " + param(likePattern(n.Values[0].(string))))
+		default:
+			b.WriteString(col + " " + sqlOps[n.Op] + " " + param(n.Values[0]))
+		}
+	}
+}
+
+// Match - check DTO (struct of the schema type or pointer to it) by validated node in memory.
+// Result is the same as of SQL condition for the row of the DTO (see FilterSchema about NULL)
+func (s *FilterSchema) Match(node FilterNode, obj interface{}) bool {
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	if v.Type() != s.typ {
+		return false
+	}
+	return s.match(node, v) == triTrue
+}
+
+// Predicate - in memory predicate of the validated node
+func (s *FilterSchema) Predicate(node FilterNode) func(obj interface{}) bool {
+	return func(obj interface{}) bool { return s.Match(node, obj) }
+}
+
+// tri - значение троичной логики SQL
+type tri int8
+
+const (
+	triFalse tri = iota
+	triTrue
+	triNull // результат сравнения с NULL
+)
+
+func toTri(b bool) tri {
+	if b {
+		return triTrue
+	}
+	return triFalse
+}
+
+func (s *FilterSchema) match(node FilterNode, v reflect.Value) tri {
+	switch n := node.(type) {
+	case nil:
+		return triTrue
+	case FilterAnd:
+		res := triTrue
+		for _, c := range n {
+			switch s.match(c, v) {
+			case triFalse:
+				return triFalse
+			case triNull:
+				res = triNull
+			}
+		}
+		return res
+	case FilterOr:
+		res := triFalse
+		for _, c := range n {
+			switch s.match(c, v) {
+			case triTrue:
+				return triTrue
+			case triNull:
+				res = triNull
+			}
+		}
+		return res
+	case FilterNot:
+		switch s.match(n.Expr, v) {
+		case triTrue:
+			return triFalse
+		case triFalse:
+			return triTrue
+		}
+		return triNull
+	case FilterCompare:
+		f := s.fields[n.Field]
+		value := v.FieldByIndex(f.index).Interface()
+		isNull := f.nullable && isZero(value)
+		if n.Op != FilterIn && n.Values[0] == nil {
+			return toTri(isNull == (n.Op == FilterEq))
+		}
+		if isNull {
+			return triNull
+		}
+		if n.Op == FilterIn {
+			for _, want := range n.Values {
+				if c, ok := compareFilterValues(value, want); ok && c == 0 {
+					return triTrue
+				}
+			}
+			return triFalse
+		}
+		want := n.Values[0]
+		if n.Op == FilterLike || n.Op == FilterILike {
+			return toTri(matchLike(fmt.Sprint(value), want.(string), n.Op == FilterILike))
+		}
+		c, ok := compareFilterValues(value, want)
+		if !ok {
+			return triFalse
+		}
+		switch n.Op {
+		case FilterEq:
+			return toTri(c == 0)
+		case FilterNe:
+			return toTri(c != 0)
+		case FilterGt:
+			return toTri(c > 0)
+		case FilterGe:
+			return toTri(c >= 0)
+		case FilterLt:
+			return toTri(c < 0)
+		case FilterLe:
+			return toTri(c <= 0)
+		}
+	}
+	return triFalse
+}
+
+func compareOrdered(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+// compareFilterValues - compare field value with typed literal: -1, 0, 1.
+// Integers are compared as integers, float64 loses precision above 2^53
+func compareFilterValues(value, want interface{}) (int, bool) {
+	v := reflect.ValueOf(value)
+	switch w := want.(type) {
+	case string:
+		if v.Kind() != reflect.String {
+			return 0, false
+		}
+		return strings.Compare(v.String(), w), true
+	case bool:
+		if v.Kind() != reflect.Bool {
+			return 0, false
+		}
+		if v.Bool() == w {
+			return 0, true
+		}
+		return 1, true
+	case time.Time:
+		t, ok := value.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		return compareOrdered(t.Before(w), t.After(w)), true
+	case int64:
+		if v.Kind() < reflect.Int || v.Kind() > reflect.Int64 {
+			return 0, false
+		}
+		return compareOrdered(v.Int() < w, v.Int() > w), true
+	case uint64:
+		if v.Kind() < reflect.Uint || v.Kind() > reflect.Uint64 {
+			return 0, false
+		}
+		return compareOrdered(v.Uint() < w, v.Uint() > w), true
+	}
+	a, ok1 := toFloat(value)
+	b, ok2 := toFloat(want)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return compareOrdered(a < b, a > b), true
+}
+
+// matchLike - match string with pattern (* - any string, ? - any symbol)
+func matchLike(s, pattern string, fold bool) bool {
+	if fold {
+		s, pattern = strings.ToLower(s), strings.ToLower(pattern)
+	}
+	sr, pr := []rune(s), []rune(pattern)
+	// жадный алгоритм с возвратом к последней звездочке
+	si, pi, star, mark := 0, 0, -1, 0
+	for si < len(sr) {
+		switch {
+		case pi < len(pr) && (pr[pi] == '?' || pr[pi] == sr[si]):
+			si++
+			pi++
+		case pi < len(pr) && pr[pi] == '*':
+			star, mark = pi, si
+			pi++
+		case star >= 0:
+			pi = star + 1
+			mark++
+			si = mark
+		default:
+			return false
+		}
+	}
+	for pi < len(pr) && pr[pi] == '*' {
+		pi++
+	}
+	return pi == len(pr)
+}
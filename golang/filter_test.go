@@ -0,0 +1,138 @@
+package golang
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type filterDTO struct {
+	ID     uint64    `json:"id"`
+	Count  int64     `json:"count"`
+	Name   string    `json:"name"`
+	Rate   float64   `json:"rate"`
+	Active bool      `json:"active"`
+	Added  time.Time `json:"addedDate"`
+	Status Status    `json:"status"`
+}
+
+func testFilterSchema() *FilterSchema {
+	return NewFilterSchema(filterDTO{}, "id", "count", "name", "rate", "active", "addedDate", "status.id").
+		SetNullable("name", "status.id")
+}
+
+func TestFilterSQL(t *testing.T) {
+	s := testFilterSchema()
+	cases := []struct {
+		expr string
+		sql  string
+		args []interface{}
+	}{
+		{"", "TRUE", nil},
+		{"id eq 5", "id = $3", []interface{}{uint64(5)}},
+		{"name like 'Ки*ї?_%' and count ge -2", "(name LIKE $3 AND count >= $4)", []interface{}{"Ки%ї_\\_\\%", int64(-2)}},
+		{"status.id eq null or not (active eq true)", "(status_id IS NULL OR NOT (active = $3))", []interface{}{true}},
+		{"name ne null", "name IS NOT NULL", nil},
+		{"id in (1, 2) and addedDate lt 2026-01-02", "(id IN ($3, $4) AND added_date < $5)",
+			[]interface{}{uint64(1), uint64(2), time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}},
+	}
+	for _, c := range cases {
+		node, err := s.Parse(c.expr)
+		if err != nil {
+			t.Errorf("%q: %v", c.expr, err)
+			continue
+		}
+		sql, args := s.SQL(node, 3)
+		if sql != c.sql || !reflect.DeepEqual(args, c.args) {
+			t.Errorf("%q: %s %v, want %s %v", c.expr, sql, args, c.sql, c.args)
+		}
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	s := testFilterSchema()
+	cases := []string{
+		"id eq",
+		"id eq 'x",
+		"unknown eq 1",
+		"id eq -1",
+		"count like '1*'",
+		"active gt true",
+		"count eq null", // поле не nullable
+		"name gt null",
+		"id in (1, 2",
+		"id eq 1 id",
+		"id between 1",
+		strings.Repeat("(", MaxFilterDepth+1) + "id eq 1" + strings.Repeat(")", MaxFilterDepth+1),
+		strings.Repeat("not ", MaxFilterDepth+1) + "id eq 1",
+		"id in (" + strings.Repeat("1, ", MaxFilterValues) + "1)",
+	}
+	for _, expr := range cases {
+		if _, err := s.Parse(expr); err == nil {
+			t.Errorf("%.60q: no error", expr)
+		}
+	}
+	// ограничение на все выражения запроса вместе
+	exprs := make([]string, MaxFilterValues/2+1)
+	for i := range exprs {
+		exprs[i] = "id in (1, 2)"
+	}
+	if _, err := s.Parse(exprs...); err == nil {
+		t.Error("values of all expressions are not limited")
+	}
+	if _, err := s.Parse(strings.Repeat("(", MaxFilterDepth-1) + "id eq 1" + strings.Repeat(")", MaxFilterDepth-1)); err != nil {
+		t.Error("allowed depth: ", err)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	s := testFilterSchema()
+	big := uint64(1)<<53 + 1 // не представимо в float64
+	dto := filterDTO{ID: big, Count: -3, Name: "Київ", Rate: 1.5, Active: true,
+		Added: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}
+	noName := dto
+	noName.Name = ""
+	cases := []struct {
+		expr string
+		obj  filterDTO
+		want bool
+	}{
+		{"id eq " + strconv.FormatUint(big, 10), dto, true},
+		{"id eq " + strconv.FormatUint(big-1, 10), dto, false},
+		{"id gt " + strconv.FormatUint(big-1, 10), dto, true},
+		{"count lt -2 and count ge -3", dto, true},
+		{"name ilike 'киї*'", dto, true},
+		{"name like 'киї*'", dto, false},
+		{"rate in (1, 1.5)", dto, true},
+		{"active eq false or addedDate ge 2026-03-01", dto, true},
+		{"not (active eq true)", dto, false},
+		// NULL (nullable поле с нулевым значением) ведет себя как в SQL
+		{"name eq null", noName, true},
+		{"name ne null", noName, false},
+		{"name ne 'Львів'", noName, false},
+		{"not (name eq 'Львів')", noName, false},
+		{"name in ('Львів')", noName, false},
+		{"not (name in ('Львів'))", noName, false},
+		{"name ne 'Львів' or id gt 0", noName, true},
+		{"not (name eq 'Львів' and id eq 0)", noName, true},
+		{"status.id eq null and status.id ne 1", dto, false},
+		// не nullable поле с нулевым значением - обычное значение
+		{"count ne 1", filterDTO{}, true},
+		{"not (active eq true)", filterDTO{}, true},
+	}
+	for _, c := range cases {
+		node, err := s.Parse(c.expr)
+		if err != nil {
+			t.Errorf("%q: %v", c.expr, err)
+			continue
+		}
+		if got := s.Match(node, &c.obj); got != c.want {
+			t.Errorf("%q on %+v: %v, want %v", c.expr, c.obj, got, c.want)
+		}
+	}
+	if s.Match(nil, Status{}) {
+		t.Error("other type matched")
+	}
+}
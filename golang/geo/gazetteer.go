@@ -0,0 +1,244 @@
+package geo
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blabu/egeonLib/golang"
+)
+
+// GazetteerEntry - запись справочника: город (без улицы), улица (без дома) или дом
+type GazetteerEntry struct {
+	Country  string  `json:"country,omitempty"`
+	Region   string  `json:"region,omitempty"`
+	City     string  `json:"city"`
+	District string  `json:"district,omitempty"`
+	Street   string  `json:"street,omitempty"`
+	Build    string  `json:"build,omitempty"`
+	PostCode string  `json:"postCode,omitempty"`
+	Lat      float64 `json:"lat"`
+	Lng      float64 `json:"lng"`
+}
+
+func (e GazetteerEntry) address(id uint64) golang.Address {
+	return golang.Address{
+		ID:       id,
+		Country:  e.Country,
+		Region:   e.Region,
+		City:     e.City,
+		District: e.District,
+		Street:   e.Street,
+		Build:    e.Build,
+		PostCode: e.PostCode,
+		Lat:      e.Lat,
+		Lng:      e.Lng,
+	}
+}
+
+// Веса частей адреса при нечетком сравнении
+var gazetteerWeights = struct {
+	Country, Region, City, Street, Build, PostCode float64
+}{Country: 0.05, Region: 0.1, City: 0.3, Street: 0.35, Build: 0.15, PostCode: 0.05}
+
+// Gazetteer - геокодер на основе локального справочника адресов (CSV или JSON)
+type Gazetteer struct {
+	MinScore     float64 // кандидаты с меньшей схожестью отбрасываются
+	MinCityScore float64 // минимальная схожесть названия города
+	MaxResults   int
+	MaxDistance  float64 // максимальное расстояние (м) для обратного геокодирования
+
+	entries []golang.Address // нормализованные записи, ID - индекс + 1
+	byCity  map[string][]int // индексы записей по названию города в нижнем регистре
+	index   *GridIndex
+}
+
+// NewGazetteer - build gazetteer from entries. Entries are normalized by golang.AddressNormalizer
+func NewGazetteer(entries []GazetteerEntry) *Gazetteer {
+	g := &Gazetteer{
+		MinScore:     0.6,
+		MinCityScore: 0.75,
+		MaxResults:   5,
+		MaxDistance:  1000,
+		entries:      make([]golang.Address, len(entries)),
+		byCity:       make(map[string][]int),
+		index:        NewGridIndex(0.01),
+	}
+	n := golang.AddressNormalizer{}
+	for i := range entries {
+		a := entries[i].address(uint64(i + 1))
+		n.Normalize(&a)
+		a.FullName = golang.UkrainianLayout.FullName(a)
+		g.entries[i] = a
+		city := strings.ToLower(a.City)
+		g.byCity[city] = append(g.byCity[city], i)
+		g.index.Insert(a)
+	}
+	return g
+}
+
+// LoadGazetteer - load gazetteer from .csv (with header row of GazetteerEntry json names) or .json (array of entries) file
+func LoadGazetteer(path string) (*Gazetteer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []GazetteerEntry
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.NewDecoder(f).Decode(&entries)
+	case ".csv":
+		entries, err = ReadGazetteerCSV(f)
+	default:
+		err = fmt.Errorf("unsupported gazetteer format %s", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewGazetteer(entries), nil
+}
+
+// ReadGazetteerCSV - read entries from CSV. First row - names of the columns (country, region, city, district, street, build, postCode, lat, lng)
+func ReadGazetteerCSV(r io.Reader) ([]GazetteerEntry, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.TrimSpace(h)] = i
+	}
+	if _, ok := cols["city"]; !ok {
+		return nil, errors.New("gazetteer csv must contain city column")
+	}
+	get := func(rec []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(rec) {
+			return rec[i]
+		}
+		return ""
+	}
+	var entries []GazetteerEntry
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		e := GazetteerEntry{
+			Country:  get(rec, "country"),
+			Region:   get(rec, "region"),
+			City:     get(rec, "city"),
+			District: get(rec, "district"),
+			Street:   get(rec, "street"),
+			Build:    get(rec, "build"),
+			PostCode: get(rec, "postCode"),
+		}
+		if e.Lat, err = strconv.ParseFloat(get(rec, "lat"), 64); err != nil {
+			return nil, fmt.Errorf("line %d: bad lat: %w", line, err)
+		}
+		if e.Lng, err = strconv.ParseFloat(get(rec, "lng"), 64); err != nil {
+			return nil, fmt.Errorf("line %d: bad lng: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+}
+
+// similarity - fuzzy similarity of the query part and entry part, accumulated with weight
+type similarity struct {
+	sum, weight float64
+}
+
+func (s *similarity) add(query, entry string, weight float64, exact bool) {
+	if len(query) == 0 {
+		return
+	}
+	s.weight += weight
+	switch {
+	case len(entry) == 0:
+	case exact:
+		if strings.EqualFold(query, entry) {
+			s.sum += weight
+		}
+	default:
+		s.sum += weight * golang.NameSimilarity(query, entry)
+	}
+}
+
+func (s similarity) score() float64 {
+	if s.weight == 0 {
+		return 0
+	}
+	return s.sum / s.weight
+}
+
+// cities - indexes of entries whose city is similar to the query city (all entries if city is empty)
+func (g *Gazetteer) cities(city string) []int {
+	city = strings.ToLower(city)
+	if idx, ok := g.byCity[city]; ok {
+		return idx
+	}
+	var res []int
+	for name, idx := range g.byCity {
+		if len(city) == 0 || golang.NameSimilarity(city, name) >= g.MinCityScore {
+			res = append(res, idx...)
+		}
+	}
+	return res
+}
+
+func (g *Gazetteer) Geocode(ctx context.Context, a golang.Address) ([]GeocodeResult, error) {
+	golang.AddressNormalizer{}.Normalize(&a)
+	w := gazetteerWeights
+	var res []GeocodeResult
+	for _, i := range g.cities(a.City) {
+		e := g.entries[i]
+		var s similarity
+		s.add(a.Country, e.Country, w.Country, false)
+		s.add(a.Region, e.Region, w.Region, false)
+		s.add(a.City, e.City, w.City, false)
+		s.add(a.Street, e.Street, w.Street, false)
+		s.add(a.Build, e.Build, w.Build, true)
+		s.add(a.PostCode, e.PostCode, w.PostCode, true)
+		// запись с лишними деталями (улица при запросе города) не должна быть лучше записи города
+		if len(a.Street) == 0 && len(e.Street) != 0 || len(a.Build) == 0 && len(e.Build) != 0 {
+			s.weight += w.Build
+		}
+		if score := s.score(); score >= g.MinScore {
+			res = append(res, GeocodeResult{Address: e, Score: score})
+		}
+	}
+	if len(res) == 0 {
+		return nil, errNotFound()
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Score == res[j].Score {
+			return res[i].Address.ID < res[j].Address.ID
+		}
+		return res[i].Score > res[j].Score
+	})
+	if g.MaxResults > 0 && len(res) > g.MaxResults {
+		res = res[:g.MaxResults]
+	}
+	return res, nil
+}
+
+func (g *Gazetteer) Reverse(ctx context.Context, p Point) (golang.Address, error) {
+	nb := g.index.NearestWithin(p, 1, g.MaxDistance)
+	if len(nb) == 0 {
+		return golang.Address{}, errNotFound()
+	}
+	return nb[0].Address, nil
+}
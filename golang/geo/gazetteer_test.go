@@ -0,0 +1,85 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blabu/egeonLib/golang"
+)
+
+func testGazetteer() *Gazetteer {
+	return NewGazetteer([]GazetteerEntry{
+		{Country: "Україна", City: "Київ", Lat: 50.4501, Lng: 30.5234},
+		{Country: "Україна", City: "Київ", Street: "вул. Хрещатик", Build: "1", Lat: 50.4474, Lng: 30.5226},
+		{Country: "Україна", City: "Львів", Lat: 49.8397, Lng: 24.0297},
+	})
+}
+
+func TestGazetteerReverseMaxDistance(t *testing.T) {
+	g := testGazetteer()
+	cases := []struct {
+		p     Point
+		found bool
+		id    uint64
+	}{
+		{Point{Lat: 50.4475, Lng: 30.5227}, true, 2},
+		{Point{Lat: 49.8398, Lng: 24.0298}, true, 3},
+		{Point{Lat: 50.5, Lng: 30.6}, false, 0}, // около 7 км от Киева
+		{Point{}, false, 0},
+	}
+	for _, c := range cases {
+		done := make(chan struct{})
+		var a golang.Address
+		var err error
+		go func() {
+			a, err = g.Reverse(context.Background(), c.p)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("%v: Reverse hangs", c.p)
+		}
+		if c.found && (err != nil || a.ID != c.id) || !c.found && err == nil {
+			t.Errorf("%v: %+v, %v", c.p, a, err)
+		}
+	}
+}
+
+type countingGeocoder struct {
+	Geocoder
+	calls int
+}
+
+func (c *countingGeocoder) Geocode(ctx context.Context, a golang.Address) ([]GeocodeResult, error) {
+	c.calls++
+	return c.Geocoder.Geocode(ctx, a)
+}
+
+func TestCachedGeocoderCopy(t *testing.T) {
+	next := &countingGeocoder{Geocoder: testGazetteer()}
+	c := NewCachedGeocoder(next, time.Minute)
+	ctx := context.Background()
+	q := golang.Address{City: "Київ", Street: "Хрещатик", Build: "1"}
+	res, err := c.Geocode(ctx, q)
+	if err != nil || len(res) == 0 {
+		t.Fatal(res, err)
+	}
+	want := res[0].Address.ID
+	res[0].Address.ID = 100
+	res[0] = GeocodeResult{}
+	res, err = c.Geocode(ctx, q)
+	if err != nil || res[0].Address.ID != want || next.calls != 1 {
+		t.Fatalf("cached result %+v, %v, calls %d", res, err, next.calls)
+	}
+	res[0].Score = -1
+	if res, _ = c.Geocode(ctx, q); res[0].Score < 0 {
+		t.Fatal("cached result is changed by caller")
+	}
+	var e golang.EgeonError
+	if _, err = c.Geocode(ctx, golang.Address{City: "Атлантида"}); !errors.As(err, &e) || e.Code != golang.NotFindItemError {
+		t.Fatalf("unknown city: %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+// Package geo - геопространственные функции для координат Address и UserProfile
+package geo
+
+import (
+	"math"
+
+	"github.com/blabu/egeonLib/golang"
+)
+
+// EarthRadius - средний радиус Земли в метрах
+const EarthRadius = golang.EarthRadius
+
+// metersPerDegree - длина одного градуса широты в метрах
+const metersPerDegree = EarthRadius * math.Pi / 180
+
+// Point - точка на поверхности Земли в градусах
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// FromAddress - coordinates of the address
+func FromAddress(a golang.Address) Point {
+	return Point{Lat: a.Lat, Lng: a.Lng}
+}
+
+// FromProfile - coordinates of the user profile
+func FromProfile(p golang.UserProfile) Point {
+	return Point{Lat: p.Lat, Lng: p.Lng}
+}
+
+// IsZero - coordinates are not set (0, 0)
+func (p Point) IsZero() bool {
+	return p.Lat == 0 && p.Lng == 0
+}
+
+// Valid - latitude in [-90, 90] and longitude in [-180, 180]
+func (p Point) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+func rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// Distance - great-circle distance between points in meters (haversine formula)
+func Distance(a, b Point) float64 {
+	dLat := rad(b.Lat - a.Lat)
+	dLng := rad(b.Lng - a.Lng)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(rad(a.Lat))*math.Cos(rad(b.Lat))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BBox - прямоугольная область (без перехода через 180 меридиан)
+type BBox struct {
+	Min Point `json:"min"` // юго-западный угол
+	Max Point `json:"max"` // северо-восточный угол
+}
+
+// Around - bounding box that contains circle with center p and radius in meters
+func Around(p Point, radius float64) BBox {
+	dLat := radius / metersPerDegree
+	dLng := 180.0
+	if cos := math.Cos(rad(p.Lat)); cos > 1e-9 {
+		dLng = math.Min(180, dLat/cos)
+	}
+	return BBox{
+		Min: Point{Lat: math.Max(-90, p.Lat-dLat), Lng: math.Max(-180, p.Lng-dLng)},
+		Max: Point{Lat: math.Min(90, p.Lat+dLat), Lng: math.Min(180, p.Lng+dLng)},
+	}
+}
+
+// BBoxOf - minimal bounding box of the points
+func BBoxOf(points ...Point) BBox {
+	if len(points) == 0 {
+		return BBox{}
+	}
+	b := BBox{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		b = b.Extend(p)
+	}
+	return b
+}
+
+// Extend - bounding box that contains b and p
+func (b BBox) Extend(p Point) BBox {
+	b.Min.Lat = math.Min(b.Min.Lat, p.Lat)
+	b.Min.Lng = math.Min(b.Min.Lng, p.Lng)
+	b.Max.Lat = math.Max(b.Max.Lat, p.Lat)
+	b.Max.Lng = math.Max(b.Max.Lng, p.Lng)
+	return b
+}
+
+// Contains - point is inside the box (borders included)
+func (b BBox) Contains(p Point) bool {
+	return p.Lat >= b.Min.Lat && p.Lat <= b.Max.Lat && p.Lng >= b.Min.Lng && p.Lng <= b.Max.Lng
+}
+
+// Intersects - boxes have common points
+func (b BBox) Intersects(o BBox) bool {
+	return b.Min.Lat <= o.Max.Lat && o.Min.Lat <= b.Max.Lat && b.Min.Lng <= o.Max.Lng && o.Min.Lng <= b.Max.Lng
+}
+
+// Center - center of the box
+func (b BBox) Center() Point {
+	return Point{Lat: (b.Min.Lat + b.Max.Lat) / 2, Lng: (b.Min.Lng + b.Max.Lng) / 2}
+}
+
+// Ring - замкнутый контур. Последняя точка может совпадать с первой
+type Ring []Point
+
+// contains - ray casting. Coordinates are treated as plane, it is enough for city district boundaries
+func (r Ring) contains(p Point) bool {
+	in := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		a, b := r[i], r[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			in = !in
+		}
+	}
+	return in
+}
+
+// Polygon - многоугольник (например граница района). Первый контур внешний, остальные - отверстия
+type Polygon []Ring
+
+// Contains - point is inside the outer ring and outside of all holes
+func (pg Polygon) Contains(p Point) bool {
+	if len(pg) == 0 || !pg[0].contains(p) {
+		return false
+	}
+	for _, hole := range pg[1:] {
+		if hole.contains(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// BBox - bounding box of the outer ring
+func (pg Polygon) BBox() BBox {
+	if len(pg) == 0 {
+		return BBox{}
+	}
+	return BBoxOf(pg[0]...)
+}
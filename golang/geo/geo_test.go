@@ -0,0 +1,95 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b Point
+		want float64 // метры
+		tol  float64
+	}{
+		{"same point", kyiv, kyiv, 0, 1e-6},
+		{"one degree of meridian", Point{Lat: 10, Lng: 20}, Point{Lat: 11, Lng: 20}, metersPerDegree, 1e-6},
+		{"one degree of equator", Point{}, Point{Lng: 1}, metersPerDegree, 1e-6},
+		{"Paris - London", Point{Lat: 48.8566, Lng: 2.3522}, Point{Lat: 51.5074, Lng: -0.1278}, 343.5e3, 1e3},
+		{"Kyiv - Lviv", kyiv, Point{Lat: 49.8397, Lng: 24.0297}, 469e3, 2e3},
+		{"antipodes", Point{Lat: 0, Lng: 0}, Point{Lat: 0, Lng: 180}, math.Pi * EarthRadius, 1e-6},
+	}
+	for _, c := range cases {
+		if d := Distance(c.a, c.b); math.Abs(d-c.want) > c.tol {
+			t.Errorf("%s: %.1f, want %.1f", c.name, d, c.want)
+		}
+		if d, back := Distance(c.a, c.b), Distance(c.b, c.a); math.Abs(d-back) > 1e-6 {
+			t.Errorf("%s: not symmetric %f %f", c.name, d, back)
+		}
+	}
+}
+
+func TestBBox(t *testing.T) {
+	b := BBoxOf(Point{Lat: 1, Lng: 5}, Point{Lat: -2, Lng: 3}, Point{Lat: 0, Lng: 7})
+	if b.Min != (Point{Lat: -2, Lng: 3}) || b.Max != (Point{Lat: 1, Lng: 7}) {
+		t.Fatalf("BBoxOf %+v", b)
+	}
+	if c := b.Center(); c != (Point{Lat: -0.5, Lng: 5}) {
+		t.Errorf("center %+v", c)
+	}
+	for _, p := range []Point{{Lat: 1, Lng: 7}, {Lat: -2, Lng: 3}, {Lat: 0, Lng: 5}} {
+		if !b.Contains(p) {
+			t.Errorf("%+v is not inside", p)
+		}
+	}
+	if b.Contains(Point{Lat: 1.1, Lng: 5}) {
+		t.Error("outer point is inside")
+	}
+	if !b.Intersects(BBox{Min: Point{Lat: 1, Lng: 7}, Max: Point{Lat: 2, Lng: 8}}) || b.Intersects(BBox{Min: Point{Lat: 2, Lng: 0}, Max: Point{Lat: 3, Lng: 10}}) {
+		t.Error("intersects")
+	}
+	if (BBoxOf() != BBox{}) {
+		t.Error("box of no points")
+	}
+
+	around := Around(kyiv, 1000)
+	for _, p := range []Point{kyiv, {Lat: kyiv.Lat + 0.0089, Lng: kyiv.Lng}, {Lat: kyiv.Lat, Lng: kyiv.Lng + 0.014}} {
+		if !around.Contains(p) || Distance(kyiv, p) > 1000 {
+			t.Errorf("%+v: in box %v, distance %.0f", p, around.Contains(p), Distance(kyiv, p))
+		}
+	}
+	if around.Contains(Point{Lat: kyiv.Lat + 0.01, Lng: kyiv.Lng}) {
+		t.Error("point 1.1 km to north is inside")
+	}
+	if pole := Around(Point{Lat: 90}, 1000); pole.Max.Lat != 90 || pole.Min.Lng != -180 || pole.Max.Lng != 180 {
+		t.Errorf("box around pole %+v", pole)
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	square := Ring{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 10}, {Lat: 10, Lng: 10}, {Lat: 10, Lng: 0}}
+	hole := Ring{{Lat: 4, Lng: 4}, {Lat: 4, Lng: 6}, {Lat: 6, Lng: 6}, {Lat: 6, Lng: 4}, {Lat: 4, Lng: 4}}
+	concave := Ring{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 10}, {Lat: 10, Lng: 10}, {Lat: 5, Lng: 5}, {Lat: 10, Lng: 0}}
+	cases := []struct {
+		name string
+		pg   Polygon
+		p    Point
+		want bool
+	}{
+		{"inside", Polygon{square}, Point{Lat: 1, Lng: 1}, true},
+		{"outside", Polygon{square}, Point{Lat: 11, Lng: 1}, false},
+		{"in hole", Polygon{square, hole}, Point{Lat: 5, Lng: 5}, false},
+		{"around hole", Polygon{square, hole}, Point{Lat: 2, Lng: 5}, true},
+		{"concave notch", Polygon{concave}, Point{Lat: 8, Lng: 5}, false},
+		{"concave body", Polygon{concave}, Point{Lat: 3, Lng: 5}, true},
+		{"empty", Polygon{}, Point{}, false},
+	}
+	for _, c := range cases {
+		if got := c.pg.Contains(c.p); got != c.want {
+			t.Errorf("%s: %v", c.name, got)
+		}
+	}
+	if b := (Polygon{square, hole}).BBox(); b.Min != (Point{}) || b.Max != (Point{Lat: 10, Lng: 10}) {
+		t.Errorf("polygon box %+v", b)
+	}
+}
@@ -0,0 +1,94 @@
+package geo
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/blabu/egeonLib/golang"
+)
+
+// GeocodeResult - найденный адрес с координатами и степенью совпадения с запросом (0..1)
+type GeocodeResult struct {
+	Address golang.Address `json:"address"`
+	Score   float64        `json:"score"`
+}
+
+// Geocoder - прямое (адрес -> координаты) и обратное (координаты -> адрес) геокодирование.
+// Реализации: Gazetteer (локальный справочник), в будущем - HTTP геокодер в стиле Nominatim
+type Geocoder interface {
+	// Geocode - candidates for the address sorted by score (the best first)
+	Geocode(ctx context.Context, a golang.Address) ([]GeocodeResult, error)
+	// Reverse - the nearest known address to the point
+	Reverse(ctx context.Context, p Point) (golang.Address, error)
+}
+
+// errNotFound - адрес не найден
+func errNotFound() error {
+	return golang.EgeonError{Code: golang.NotFindItemError, Description: golang.Errors["notFindRecord"].Error()}
+}
+
+// Locate - fill Lat and Lng of the address by the best candidate of the geocoder
+func Locate(ctx context.Context, g Geocoder, a *golang.Address) error {
+	res, err := g.Geocode(ctx, *a)
+	if err != nil {
+		return err
+	}
+	if len(res) == 0 {
+		return errNotFound()
+	}
+	a.Lat, a.Lng = res[0].Address.Lat, res[0].Address.Lng
+	return nil
+}
+
+// Таблицы LocalCache геокодера
+const (
+	geocodeTable uint32 = iota
+	reverseTable
+)
+
+// ReverseCachePrecision - точность geohash ключа кеша обратного геокодирования (9 символов - около 5 метров)
+const ReverseCachePrecision = 9
+
+// CachedGeocoder - кеширует успешные ответы геокодера в LocalCache
+type CachedGeocoder struct {
+	next  Geocoder
+	cache *golang.LocalCache
+}
+
+// NewCachedGeocoder - decorator with results life time ttl (0 - forever)
+func NewCachedGeocoder(next Geocoder, ttl time.Duration) *CachedGeocoder {
+	return &CachedGeocoder{next: next, cache: golang.GetNewNamedCache("geocoder", ttl, geocodeTable, reverseTable)}
+}
+
+// geocodeKey - key of the normalized address
+func geocodeKey(a golang.Address) string {
+	n := golang.AddressNormalizer{}
+	n.Normalize(&a)
+	return strings.ToLower(strings.Join([]string{a.Country, a.Region, a.City, a.District, a.Street, a.Build, a.PostCode}, "|"))
+}
+
+func (c *CachedGeocoder) Geocode(ctx context.Context, a golang.Address) ([]GeocodeResult, error) {
+	key := geocodeKey(a)
+	// в кеше хранится своя копия, вызывающий может изменять полученный срез
+	if res, ok := c.cache.GetItem(geocodeTable, key).([]GeocodeResult); ok {
+		return append([]GeocodeResult(nil), res...), nil
+	}
+	res, err := c.next.Geocode(ctx, a)
+	if err == nil && len(res) != 0 {
+		c.cache.StoreItem(geocodeTable, key, append([]GeocodeResult(nil), res...))
+	}
+	return res, err
+}
+
+func (c *CachedGeocoder) Reverse(ctx context.Context, p Point) (golang.Address, error) {
+	key := Geohash(p, ReverseCachePrecision)
+	if res, ok := c.cache.GetItem(reverseTable, key).(golang.Address); ok {
+		return res, nil
+	}
+	res, err := c.next.Reverse(ctx, p)
+	if err == nil {
+		c.cache.StoreItem(reverseTable, key, res)
+	}
+	return res, err
+}
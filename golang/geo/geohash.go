@@ -0,0 +1,103 @@
+package geo
+
+import (
+	"errors"
+	"strings"
+)
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxGeohashPrecision - 12 символов дают точность порядка сантиметров
+const MaxGeohashPrecision = 12
+
+var errBadGeohash = errors.New("geohash contains invalid symbol")
+
+// Geohash - encode point to geohash string with precision symbols (1..12).
+// Points near each other have common prefix, so geohash can be used as index or cache key
+func Geohash(p Point, precision int) string {
+	if precision < 1 {
+		precision = 1
+	} else if precision > MaxGeohashPrecision {
+		precision = MaxGeohashPrecision
+	}
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+	var b strings.Builder
+	b.Grow(precision)
+	even := true // чётные биты кодируют долготу
+	idx, bit := 0, 0
+	for b.Len() < precision {
+		rng, v := &latRange, p.Lat
+		if even {
+			rng, v = &lngRange, p.Lng
+		}
+		mid := (rng[0] + rng[1]) / 2
+		idx <<= 1
+		if v >= mid {
+			idx |= 1
+			rng[0] = mid
+		} else {
+			rng[1] = mid
+		}
+		even = !even
+		if bit++; bit == 5 {
+			b.WriteByte(geohashAlphabet[idx])
+			idx, bit = 0, 0
+		}
+	}
+	return b.String()
+}
+
+// DecodeGeohash - area of the geohash
+func DecodeGeohash(hash string) (BBox, error) {
+	latRange := [2]float64{-90, 90}
+	lngRange := [2]float64{-180, 180}
+	even := true
+	for i := 0; i < len(hash); i++ {
+		idx := strings.IndexByte(geohashAlphabet, hash[i])
+		if idx < 0 {
+			return BBox{}, errBadGeohash
+		}
+		for mask := 16; mask != 0; mask >>= 1 {
+			rng := &latRange
+			if even {
+				rng = &lngRange
+			}
+			mid := (rng[0] + rng[1]) / 2
+			if idx&mask != 0 {
+				rng[0] = mid
+			} else {
+				rng[1] = mid
+			}
+			even = !even
+		}
+	}
+	return BBox{Min: Point{Lat: latRange[0], Lng: lngRange[0]}, Max: Point{Lat: latRange[1], Lng: lngRange[1]}}, nil
+}
+
+// GeohashNeighbors - geohashes of the same precision around hash (8 cells, clockwise from north)
+func GeohashNeighbors(hash string) ([]string, error) {
+	box, err := DecodeGeohash(hash)
+	if err != nil {
+		return nil, err
+	}
+	c := box.Center()
+	dLat := box.Max.Lat - box.Min.Lat
+	dLng := box.Max.Lng - box.Min.Lng
+	steps := [8][2]float64{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+	res := make([]string, 0, len(steps))
+	for _, s := range steps {
+		lat := c.Lat + s[0]*dLat
+		if lat > 90 || lat < -90 {
+			continue
+		}
+		lng := c.Lng + s[1]*dLng
+		if lng > 180 {
+			lng -= 360
+		} else if lng < -180 {
+			lng += 360
+		}
+		res = append(res, Geohash(Point{Lat: lat, Lng: lng}, len(hash)))
+	}
+	return res, nil
+}
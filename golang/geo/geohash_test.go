@@ -0,0 +1,56 @@
+package geo
+
+import (
+	"testing"
+)
+
+func TestGeohash(t *testing.T) {
+	cases := []struct {
+		p         Point
+		precision int
+		want      string
+	}{
+		{Point{Lat: 57.64911, Lng: 10.40744}, 11, "u4pruydqqvj"},
+		{Point{Lat: 42.6, Lng: -5.6}, 5, "ezs42"},
+		{Point{Lat: -25.382708, Lng: -49.265506}, 8, "6gkzwgjz"},
+		{Point{Lat: 57.64911, Lng: 10.40744}, 0, "u"},
+		{Point{Lat: 57.64911, Lng: 10.40744}, 20, "u4pruydqqvj8"},
+	}
+	for _, c := range cases {
+		got := Geohash(c.p, c.precision)
+		if got != c.want {
+			t.Errorf("%+v/%d: %s, want %s", c.p, c.precision, got, c.want)
+		}
+		box, err := DecodeGeohash(got)
+		if err != nil || !box.Contains(c.p) {
+			t.Errorf("%s: box %+v does not contain %+v: %v", got, box, c.p, err)
+		}
+	}
+	if _, err := DecodeGeohash("ezs4a"); err == nil {
+		t.Error("invalid symbol accepted")
+	}
+}
+
+func TestGeohashNeighbors(t *testing.T) {
+	hash := "ezs42"
+	box, _ := DecodeGeohash(hash)
+	neighbors, err := GeohashNeighbors(hash)
+	if err != nil || len(neighbors) != 8 {
+		t.Fatalf("%v, %v", neighbors, err)
+	}
+	if neighbors[0] != "ezs48" || neighbors[2] != "ezs43" || neighbors[4] != "ezs40" || neighbors[6] != "ezefr" {
+		t.Errorf("neighbors %v", neighbors)
+	}
+	seen := map[string]bool{hash: true}
+	for _, n := range neighbors {
+		nb, _ := DecodeGeohash(n)
+		if seen[n] || len(n) != len(hash) || !nb.Intersects(box) {
+			t.Errorf("neighbor %s %+v", n, nb)
+		}
+		seen[n] = true
+	}
+	// у полюса соседей севернее нет
+	if polar, _ := GeohashNeighbors(Geohash(Point{Lat: 89.99, Lng: 0}, 3)); len(polar) != 5 {
+		t.Errorf("polar neighbors %v", polar)
+	}
+}
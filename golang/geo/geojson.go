@@ -0,0 +1,103 @@
+package geo
+
+import (
+	"encoding/json"
+
+	"github.com/blabu/egeonLib/golang"
+)
+
+// Geometry - геометрия GeoJSON. Координаты в порядке [lng, lat]
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// Feature - объект GeoJSON
+type Feature struct {
+	Type       string                 `json:"type"`
+	ID         interface{}            `json:"id,omitempty"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// FeatureCollection - коллекция объектов GeoJSON (RFC 7946)
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	BBox     []float64 `json:"bbox,omitempty"`
+	Features []Feature `json:"features"`
+}
+
+// PointGeometry - GeoJSON geometry of the point
+func PointGeometry(p Point) *Geometry {
+	return &Geometry{Type: "Point", Coordinates: [2]float64{p.Lng, p.Lat}}
+}
+
+// PolygonGeometry - GeoJSON geometry of the polygon. Rings are closed if needed
+func PolygonGeometry(pg Polygon) *Geometry {
+	rings := make([][][2]float64, len(pg))
+	for i, r := range pg {
+		coords := make([][2]float64, 0, len(r)+1)
+		for _, p := range r {
+			coords = append(coords, [2]float64{p.Lng, p.Lat})
+		}
+		if len(r) != 0 && r[0] != r[len(r)-1] {
+			coords = append(coords, [2]float64{r[0].Lng, r[0].Lat})
+		}
+		rings[i] = coords
+	}
+	return &Geometry{Type: "Polygon", Coordinates: rings}
+}
+
+// AddressFeature - GeoJSON feature of the address. Address without coordinates has null geometry
+func AddressFeature(a golang.Address) Feature {
+	f := Feature{
+		Type: "Feature",
+		ID:   a.ID,
+		Properties: map[string]interface{}{
+			"country": a.Country,
+			"region":  a.Region,
+			"city":    a.City,
+			"street":  a.Street,
+			"build":   a.Build,
+		},
+	}
+	if len(a.FullName) != 0 {
+		f.Properties["fullName"] = a.FullName
+	}
+	if len(a.Apartment) != 0 {
+		f.Properties["apartment"] = a.Apartment
+	}
+	if len(a.PostCode) != 0 {
+		f.Properties["postCode"] = a.PostCode
+	}
+	if p := FromAddress(a); !p.IsZero() {
+		f.Geometry = PointGeometry(p)
+	}
+	return f
+}
+
+// AddressCollection - GeoJSON feature collection of addresses with bounding box
+func AddressCollection(addrs []golang.Address) FeatureCollection {
+	fc := FeatureCollection{Type: "FeatureCollection", Features: make([]Feature, 0, len(addrs))}
+	var box BBox
+	hasBox := false
+	for i := range addrs {
+		fc.Features = append(fc.Features, AddressFeature(addrs[i]))
+		if p := FromAddress(addrs[i]); !p.IsZero() {
+			if hasBox {
+				box = box.Extend(p)
+			} else {
+				box, hasBox = BBox{Min: p, Max: p}, true
+			}
+		}
+	}
+	if hasBox {
+		fc.BBox = []float64{box.Min.Lng, box.Min.Lat, box.Max.Lng, box.Max.Lat}
+	}
+	return fc
+}
+
+// AddressesGeoJSON - encode addresses as GeoJSON feature collection
+func AddressesGeoJSON(addrs []golang.Address) ([]byte, error) {
+	return json.Marshal(AddressCollection(addrs))
+}
@@ -0,0 +1,66 @@
+package geo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/blabu/egeonLib/golang"
+)
+
+func TestAddressesGeoJSON(t *testing.T) {
+	addrs := []golang.Address{
+		{ID: 1, City: "Київ", Street: "Хрещатик", Build: "1", PostCode: "01001", Lat: 50.45, Lng: 30.52},
+		{ID: 2, City: "Львів", Lat: 49.84, Lng: 24.03, Apartment: "5"},
+		{ID: 3, City: "Без координат"},
+	}
+	data, err := AddressesGeoJSON(addrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fc struct {
+		Type     string    `json:"type"`
+		BBox     []float64 `json:"bbox"`
+		Features []struct {
+			Type     string                 `json:"type"`
+			ID       uint64                 `json:"id"`
+			Geometry *Geometry              `json:"geometry"`
+			Props    map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	if err = json.Unmarshal(data, &fc); err != nil {
+		t.Fatal(err)
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 3 {
+		t.Fatalf("%s", data)
+	}
+	// координаты GeoJSON в порядке [lng, lat]
+	if want := []float64{24.03, 49.84, 30.52, 50.45}; len(fc.BBox) != 4 || fc.BBox[0] != want[0] || fc.BBox[1] != want[1] || fc.BBox[2] != want[2] || fc.BBox[3] != want[3] {
+		t.Errorf("bbox %v", fc.BBox)
+	}
+	first := fc.Features[0]
+	coords, _ := first.Geometry.Coordinates.([]interface{})
+	if first.Type != "Feature" || first.ID != 1 || first.Geometry.Type != "Point" || len(coords) != 2 || coords[0] != 30.52 || coords[1] != 50.45 {
+		t.Errorf("feature %+v", first)
+	}
+	if first.Props["postCode"] != "01001" || first.Props["city"] != "Київ" {
+		t.Errorf("properties %v", first.Props)
+	}
+	if fc.Features[1].Props["apartment"] != "5" {
+		t.Errorf("apartment %v", fc.Features[1].Props)
+	}
+	if fc.Features[2].Geometry != nil {
+		t.Errorf("address without coordinates has geometry %+v", fc.Features[2].Geometry)
+	}
+}
+
+func TestPolygonGeometry(t *testing.T) {
+	g := PolygonGeometry(Polygon{{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 1, Lng: 1}}})
+	rings := g.Coordinates.([][][2]float64)
+	if g.Type != "Polygon" || len(rings) != 1 || len(rings[0]) != 4 || rings[0][0] != rings[0][3] || rings[0][1] != [2]float64{1, 0} {
+		t.Errorf("polygon %+v", g)
+	}
+	closed := PolygonGeometry(Polygon{{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 1, Lng: 1}, {Lat: 0, Lng: 0}}})
+	if rings = closed.Coordinates.([][][2]float64); len(rings[0]) != 4 {
+		t.Errorf("closed ring is closed again: %v", rings)
+	}
+}
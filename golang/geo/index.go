@@ -0,0 +1,265 @@
+package geo
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/blabu/egeonLib/golang"
+)
+
+// cellKey - ячейка сетки
+type cellKey struct {
+	lat, lng int32
+}
+
+// minCellSize - минимальный размер ячейки (около 10 см), номера ячеек помещаются в int32 с запасом
+const minCellSize = 1e-6
+
+// GridIndex - пространственный индекс адресов на равномерной сетке.
+// Безопасен для одновременного использования. Переход через 180 меридиан не поддерживается
+type GridIndex struct {
+	cellSize float64 // размер ячейки в градусах
+	mt       sync.RWMutex
+	cells    map[cellKey][]golang.Address
+	count    int
+	min, max cellKey // границы занятых ячеек (при удалении не сужаются)
+}
+
+// NewGridIndex - index with cells of cellSize degrees (0.01 is about 1 km).
+// Not positive size is replaced by 0.01, size less than 1e-6 is replaced by 1e-6
+func NewGridIndex(cellSize float64) *GridIndex {
+	if cellSize <= 0 {
+		cellSize = 0.01
+	}
+	if cellSize < minCellSize {
+		cellSize = minCellSize
+	}
+	return &GridIndex{cellSize: cellSize, cells: make(map[cellKey][]golang.Address)}
+}
+
+// IndexAddresses - build index of addresses, addresses without coordinates are skipped
+func IndexAddresses(addrs []golang.Address, cellSize float64) *GridIndex {
+	idx := NewGridIndex(cellSize)
+	for i := range addrs {
+		idx.Insert(addrs[i])
+	}
+	return idx
+}
+
+// key - cell of the point, coordinates out of range are clamped so cell numbers always fit int32
+func (g *GridIndex) key(p Point) cellKey {
+	lat := math.Max(-90, math.Min(90, p.Lat))
+	lng := math.Max(-180, math.Min(180, p.Lng))
+	if math.IsNaN(lat) || math.IsNaN(lng) {
+		lat, lng = 0, 0
+	}
+	return cellKey{lat: int32(math.Floor(lat / g.cellSize)), lng: int32(math.Floor(lng / g.cellSize))}
+}
+
+// Insert - add address to the index. Return false if address has no valid coordinates
+func (g *GridIndex) Insert(a golang.Address) bool {
+	p := FromAddress(a)
+	if p.IsZero() || !p.Valid() {
+		return false
+	}
+	k := g.key(p)
+	g.mt.Lock()
+	if g.count == 0 && len(g.cells) == 0 {
+		g.min, g.max = k, k
+	}
+	g.min = cellKey{lat: minInt32(g.min.lat, k.lat), lng: minInt32(g.min.lng, k.lng)}
+	g.max = cellKey{lat: maxInt32(g.max.lat, k.lat), lng: maxInt32(g.max.lng, k.lng)}
+	g.cells[k] = append(g.cells[k], a)
+	g.count++
+	g.mt.Unlock()
+	return true
+}
+
+// Remove - remove address with id from the index
+func (g *GridIndex) Remove(a golang.Address) bool {
+	k := g.key(FromAddress(a))
+	g.mt.Lock()
+	defer g.mt.Unlock()
+	cell := g.cells[k]
+	for i := range cell {
+		if cell[i].ID == a.ID {
+			cell[i] = cell[len(cell)-1]
+			if len(cell) == 1 {
+				delete(g.cells, k)
+			} else {
+				g.cells[k] = cell[:len(cell)-1]
+			}
+			g.count--
+			return true
+		}
+	}
+	return false
+}
+
+// Len - count of addresses in the index
+func (g *GridIndex) Len() int {
+	g.mt.RLock()
+	defer g.mt.RUnlock()
+	return g.count
+}
+
+// Within - addresses inside the box
+func (g *GridIndex) Within(b BBox) []golang.Address {
+	min, max := g.key(b.Min), g.key(b.Max)
+	g.mt.RLock()
+	defer g.mt.RUnlock()
+	var res []golang.Address
+	for lat := min.lat; lat <= max.lat; lat++ {
+		for lng := min.lng; lng <= max.lng; lng++ {
+			for _, a := range g.cells[cellKey{lat: lat, lng: lng}] {
+				if b.Contains(FromAddress(a)) {
+					res = append(res, a)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// Neighbor - адрес и расстояние до него в метрах
+type Neighbor struct {
+	Address  golang.Address
+	Distance float64
+}
+
+// Nearest - n nearest addresses to p sorted by distance.
+// Cells are visited by rings around p until the nearest ring is farther than the n-th found address
+// or the ring is out of the occupied cells. If rings cost more than all occupied cells, they are scanned linearly
+func (g *GridIndex) Nearest(p Point, n int) []Neighbor {
+	return g.NearestWithin(p, n, 0)
+}
+
+// NearestWithin - n nearest addresses not farther than maxDistance meters (0 - unlimited).
+// Rings farther than maxDistance are not visited
+func (g *GridIndex) NearestWithin(p Point, n int, maxDistance float64) []Neighbor {
+	if n <= 0 {
+		return nil
+	}
+	g.mt.RLock()
+	defer g.mt.RUnlock()
+	if g.count == 0 {
+		return nil
+	}
+	center := g.key(p)
+	// кольцо, за которым занятых ячеек нет
+	maxR := maxInt64(
+		maxInt64(int64(center.lat)-int64(g.min.lat), int64(g.max.lat)-int64(center.lat)),
+		maxInt64(int64(center.lng)-int64(g.min.lng), int64(g.max.lng)-int64(center.lng)))
+	res := make([]Neighbor, 0, n+1)
+	visit := func(lat, lng int64) {
+		for _, a := range g.cells[cellKey{lat: int32(lat), lng: int32(lng)}] {
+			res = insertNeighbor(res, Neighbor{Address: a, Distance: Distance(p, FromAddress(a))}, n, maxDistance)
+		}
+	}
+	visited := 0
+	for r := int64(0); r <= maxR; r++ {
+		ring := g.ringDistance(p, r)
+		if len(res) == n && res[n-1].Distance < ring || maxDistance > 0 && ring > maxDistance {
+			return res
+		}
+		if visited += ringCells(r); visited > len(g.cells) {
+			return g.scan(p, n, maxDistance)
+		}
+		clat, clng := int64(center.lat), int64(center.lng)
+		if r == 0 {
+			visit(clat, clng)
+			continue
+		}
+		// только ячейки на границе кольца: верхняя и нижняя строки, затем боковые столбцы
+		for lng := clng - r; lng <= clng+r; lng++ {
+			visit(clat-r, lng)
+			visit(clat+r, lng)
+		}
+		for lat := clat - r + 1; lat < clat+r; lat++ {
+			visit(lat, clng-r)
+			visit(lat, clng+r)
+		}
+	}
+	return res
+}
+
+// ringCells - count of cells in ring r
+func ringCells(r int64) int {
+	if r == 0 {
+		return 1
+	}
+	return int(8 * r)
+}
+
+// scan - n nearest addresses by all cells
+func (g *GridIndex) scan(p Point, n int, maxDistance float64) []Neighbor {
+	res := make([]Neighbor, 0, n+1)
+	for _, cell := range g.cells {
+		for _, a := range cell {
+			res = insertNeighbor(res, Neighbor{Address: a, Distance: Distance(p, FromAddress(a))}, n, maxDistance)
+		}
+	}
+	return res
+}
+
+func minInt32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// ringDistance - lower bound of the distance from p to any point in ring r or farther
+func (g *GridIndex) ringDistance(p Point, r int64) float64 {
+	if r == 0 {
+		return 0
+	}
+	deg := float64(r-1) * g.cellSize
+	lat := math.Min(90, math.Abs(p.Lat)+deg+g.cellSize)
+	// градус долготы короче градуса широты, берем минимальный на рассматриваемой широте
+	return deg * metersPerDegree * math.Cos(rad(lat))
+}
+
+// insertNeighbor - insert into sorted list, keep not more than n elements not farther than maxDistance (0 - unlimited)
+func insertNeighbor(list []Neighbor, nb Neighbor, n int, maxDistance float64) []Neighbor {
+	if len(list) == n && list[n-1].Distance <= nb.Distance || maxDistance > 0 && nb.Distance > maxDistance {
+		return list
+	}
+	i := sort.Search(len(list), func(i int) bool { return list[i].Distance > nb.Distance })
+	list = append(list, Neighbor{})
+	copy(list[i+1:], list[i:])
+	list[i] = nb
+	if len(list) > n {
+		list = list[:n]
+	}
+	return list
+}
+
+// NearestAddresses - n nearest addresses from slice without index (full scan)
+func NearestAddresses(addrs []golang.Address, p Point, n int) []Neighbor {
+	if n <= 0 {
+		return nil
+	}
+	res := make([]Neighbor, 0, n+1)
+	for i := range addrs {
+		if ap := FromAddress(addrs[i]); !ap.IsZero() {
+			res = insertNeighbor(res, Neighbor{Address: addrs[i], Distance: Distance(p, ap)}, n, 0)
+		}
+	}
+	return res
+}
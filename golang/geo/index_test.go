@@ -0,0 +1,86 @@
+package geo
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/blabu/egeonLib/golang"
+)
+
+var kyiv = Point{Lat: 50.4501, Lng: 30.5234}
+
+func TestGridIndexNearestFarQuery(t *testing.T) {
+	cases := []struct {
+		cellSize float64
+		query    Point
+	}{
+		{0.01, Point{}},
+		{0.01, Point{Lat: -89.9, Lng: -179.9}},
+		{1e-12, Point{}}, // размер меньше минимального
+		{0.01, Point{Lat: 1e30, Lng: -1e30}},
+	}
+	for _, c := range cases {
+		idx := NewGridIndex(c.cellSize)
+		idx.Insert(golang.Address{ID: 1, Lat: kyiv.Lat, Lng: kyiv.Lng})
+		done := make(chan []Neighbor, 1)
+		go func() { done <- idx.Nearest(c.query, 3) }()
+		select {
+		case res := <-done:
+			if len(res) != 1 || res[0].Address.ID != 1 {
+				t.Errorf("%v %v: %+v", c.cellSize, c.query, res)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("%v %v: Nearest hangs", c.cellSize, c.query)
+		}
+	}
+}
+
+func TestGridIndexNearestMatchesScan(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	addrs := make([]golang.Address, 2000)
+	for i := range addrs {
+		addrs[i] = golang.Address{ID: uint64(i + 1), Lat: 44 + rnd.Float64()*8, Lng: 22 + rnd.Float64()*18}
+	}
+	for _, cellSize := range []float64{0.001, 0.05, 1} {
+		idx := IndexAddresses(addrs, cellSize)
+		for i := 0; i < 50; i++ {
+			q := Point{Lat: 40 + rnd.Float64()*16, Lng: 18 + rnd.Float64()*26}
+			got := idx.Nearest(q, 5)
+			want := NearestAddresses(addrs, q, 5)
+			if len(got) != len(want) {
+				t.Fatalf("cell %v %v: %d results, want %d", cellSize, q, len(got), len(want))
+			}
+			for j := range want {
+				if got[j].Address.ID != want[j].Address.ID {
+					t.Fatalf("cell %v %v: %d-th is %d, want %d", cellSize, q, j, got[j].Address.ID, want[j].Address.ID)
+				}
+			}
+		}
+	}
+}
+
+func TestGridIndexRemove(t *testing.T) {
+	idx := NewGridIndex(0.01)
+	a := golang.Address{ID: 1, Lat: kyiv.Lat, Lng: kyiv.Lng}
+	idx.Insert(a)
+	if !idx.Remove(a) || idx.Len() != 0 || idx.Nearest(kyiv, 1) != nil {
+		t.Fatal("address is not removed")
+	}
+	if idx.Insert(golang.Address{ID: 2}) {
+		t.Fatal("address without coordinates is indexed")
+	}
+}
+
+func TestNearestNonPositiveCount(t *testing.T) {
+	addrs := []golang.Address{{ID: 1, Lat: kyiv.Lat, Lng: kyiv.Lng}}
+	idx := IndexAddresses(addrs, 0.01)
+	for _, n := range []int{0, -1} {
+		if res := NearestAddresses(addrs, kyiv, n); res != nil {
+			t.Errorf("NearestAddresses(%d): %v", n, res)
+		}
+		if res := idx.Nearest(kyiv, n); res != nil {
+			t.Errorf("Nearest(%d): %v", n, res)
+		}
+	}
+}
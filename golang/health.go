@@ -0,0 +1,246 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	retry "github.com/hashicorp/go-retryablehttp"
+)
+
+// Статусы проверок здоровья сервиса
+const (
+	HealthUp       = "up"
+	HealthDown     = "down"
+	HealthWarn     = "warn"     // Проверка прошла, но зависимость работает на грани
+	HealthDegraded = "degraded" // Не прошла одна из некритичных проверок
+)
+
+// HealthCheckFunc - проверка одной зависимости сервиса. nil - зависимость работает
+type HealthCheckFunc func(ctx context.Context) error
+
+// HealthWarning - checks return it when dependency works but close to its limits.
+// Warning does not make service unready
+type HealthWarning struct {
+	Description string
+}
+
+func (w HealthWarning) Error() string {
+	return w.Description
+}
+
+// HealthCheck - описание проверки зависимости сервиса
+type HealthCheck struct {
+	Name     string
+	Check    HealthCheckFunc
+	Timeout  time.Duration // Максимальное время выполнения проверки (по умолчанию 5 секунд)
+	CacheTTL time.Duration // Время, в течение которого результат проверки переиспользуется
+	Critical bool          // Если критичная проверка не прошла сервис не готов принимать запросы
+}
+
+// CheckResult - результат одной проверки
+type CheckResult struct {
+	Name      string        `json:"name"`
+	Status    string        `json:"status"`
+	Critical  bool          `json:"critical"`
+	Error     string        `json:"error,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	CheckedAt time.Time     `json:"checkedAt"`
+}
+
+// HealthReport - результат всех проверок сервиса
+type HealthReport struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks,omitempty"`
+}
+
+type registeredCheck struct {
+	HealthCheck
+	mt   sync.Mutex
+	last CheckResult
+}
+
+// run - run the check or return cached result.
+// Результат не кешируется, если проверка прервана отменой контекста вызывающего (клиент закрыл соединение),
+// а не собственным таймаутом проверки
+func (rc *registeredCheck) run(parent context.Context) CheckResult {
+	rc.mt.Lock()
+	defer rc.mt.Unlock()
+	if !rc.last.CheckedAt.IsZero() && time.Since(rc.last.CheckedAt) < rc.CacheTTL {
+		return rc.last
+	}
+	ctx, cancel := context.WithTimeout(parent, rc.Timeout)
+	defer cancel()
+	start := time.Now()
+	res := CheckResult{Name: rc.Name, Status: HealthUp, Critical: rc.Critical, CheckedAt: start}
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("check panic: %v", r)
+			}
+		}()
+		done <- rc.Check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	res.Duration = time.Since(start)
+	var warn HealthWarning
+	if errors.As(err, &warn) {
+		res.Status = HealthWarn
+		res.Error = warn.Description
+	} else if err != nil {
+		res.Status = HealthDown
+		res.Error = err.Error()
+		if parent.Err() != nil {
+			return res
+		}
+		GetLogger().Warn(ctx, "health check failed", "check", rc.Name, "critical", rc.Critical, "error", err)
+	}
+	rc.last = res
+	return res
+}
+
+// Health - набор проверок зависимостей сервиса (база данных, кеш, другие сервисы)
+type Health struct {
+	mt     sync.RWMutex
+	checks []*registeredCheck
+}
+
+// NewHealth - create empty set of checks
+func NewHealth() *Health {
+	return &Health{}
+}
+
+// Register - add check of the dependency
+func (h *Health) Register(check HealthCheck) {
+	if check.Timeout <= 0 {
+		check.Timeout = 5 * time.Second
+	}
+	h.mt.Lock()
+	h.checks = append(h.checks, &registeredCheck{HealthCheck: check})
+	h.mt.Unlock()
+}
+
+// Check - run all checks in parallel and form report
+// onlyCritical - run only critical checks (readiness)
+func (h *Health) Check(ctx context.Context, onlyCritical bool) HealthReport {
+	h.mt.RLock()
+	checks := make([]*registeredCheck, 0, len(h.checks))
+	for _, c := range h.checks {
+		if !onlyCritical || c.Critical {
+			checks = append(checks, c)
+		}
+	}
+	h.mt.RUnlock()
+	report := HealthReport{Status: HealthUp, Checks: make([]CheckResult, len(checks))}
+	var wg sync.WaitGroup
+	for i := range checks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			report.Checks[i] = checks[i].run(ctx)
+		}(i)
+	}
+	wg.Wait()
+	for _, res := range report.Checks {
+		if res.Status == HealthUp {
+			continue
+		}
+		if res.Critical && res.Status == HealthDown {
+			report.Status = HealthDown
+			break
+		}
+		report.Status = HealthDegraded
+	}
+	return report
+}
+
+// CheckService - run all checks. Return error if some critical check failed.
+// Can be used as checkService in AddServerStatsHandler and GetServerStatusHandler
+func (h *Health) CheckService() error {
+	report := h.Check(context.Background(), false)
+	if report.Status != HealthDown {
+		return nil
+	}
+	var failed []string
+	for _, res := range report.Checks {
+		if res.Critical && res.Status == HealthDown {
+			failed = append(failed, res.Name+": "+res.Error)
+		}
+	}
+	return errors.New(strings.Join(failed, "; "))
+}
+
+func healthCode(report HealthReport) int {
+	if report.Status == HealthDown {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+// LiveHandler - liveness probe. Process is alive while it can answer
+func (h *Health) LiveHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, HealthReport{Status: HealthUp})
+	}
+}
+
+// ReadyHandler - readiness probe. Run only critical checks, answer 503 if some of them failed
+func (h *Health) ReadyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := h.Check(r.Context(), true)
+		writeJSON(w, healthCode(report), HealthReport{Status: report.Status})
+	}
+}
+
+// HealthHandler - detailed report of all checks. Answer 503 if some critical check failed
+func (h *Health) HealthHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := h.Check(r.Context(), false)
+		writeJSON(w, healthCode(report), report)
+	}
+}
+
+// AddHealthHandlers - add prefix/live, prefix/ready and prefix/health routes to the router
+func (h *Health) AddHealthHandlers(router gin.IRoutes, prefix string) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	router.GET(prefix+"/live", gin.WrapF(h.LiveHandler()))
+	router.GET(prefix+"/ready", gin.WrapF(h.ReadyHandler()))
+	router.GET(prefix+"/health", gin.WrapF(h.HealthHandler()))
+}
+
+// DatabaseCheck - check database by ping and pool statistic. stats can be nil
+// Return error when all connections of the pool are in use
+func DatabaseCheck(ping func(ctx context.Context) error, stats func() DBStats) HealthCheckFunc {
+	return func(ctx context.Context) error {
+		if err := ping(ctx); err != nil {
+			return err
+		}
+		if stats == nil {
+			return nil
+		}
+		if st := stats(); st.MaxOpenConnections > 0 && st.InUse >= st.MaxOpenConnections {
+			return fmt.Errorf("connection pool exhausted: %d of %d in use, waited %d times", st.InUse, st.MaxOpenConnections, st.WaitCount)
+		}
+		return nil
+	}
+}
+
+// ServiceCheck - check downstream service by GET request to statusURL with DoRequest
+func ServiceCheck(client *retry.Client, statusURL url.URL) HealthCheckFunc {
+	return func(ctx context.Context) error {
+		_, err := DoRequest(ctx, client, http.MethodGet, statusURL, nil)
+		return err
+	}
+}
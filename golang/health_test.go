@@ -0,0 +1,82 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	retry "github.com/hashicorp/go-retryablehttp"
+)
+
+func TestHealthCheckCanceledNotCached(t *testing.T) {
+	h := NewHealth()
+	h.Register(HealthCheck{
+		Name:     "slow",
+		Critical: true,
+		CacheTTL: time.Minute,
+		Check: func(ctx context.Context) error {
+			return ctx.Err()
+		},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if report := h.Check(ctx, false); report.Status != HealthDown {
+		t.Fatalf("canceled check: %+v", report)
+	}
+	if report := h.Check(context.Background(), false); report.Status != HealthUp {
+		t.Fatalf("result of canceled check is cached: %+v", report)
+	}
+}
+
+func TestHealthCheckTimeoutCached(t *testing.T) {
+	var calls int32
+	h := NewHealth()
+	h.Register(HealthCheck{
+		Name:     "hang",
+		Timeout:  10 * time.Millisecond,
+		CacheTTL: time.Minute,
+		Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	for i := 0; i < 2; i++ {
+		if report := h.Check(context.Background(), false); report.Status != HealthDegraded {
+			t.Fatalf("not critical timeout: %+v", report)
+		}
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("check called %d times", calls)
+	}
+	if err := h.CheckService(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServiceCheckContext(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+	u, _ := url.Parse(srv.URL)
+	client := retry.NewClient()
+	client.Logger = nil
+	client.RetryMax = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := ServiceCheck(client, *u)(ctx)
+	if err == nil || time.Since(start) > 2*time.Second {
+		t.Fatalf("request is not bound to context: %v after %v", err, time.Since(start))
+	}
+}
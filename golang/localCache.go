@@ -9,6 +9,8 @@ import (
 var allStores []LocalCache // Все кеши базы данных собраны здесь
 var mt sync.Mutex          // Этот мьтекс защищает изменения в allStores
 
+const localCacheMetricName = "localCache"
+
 // entry - конкретная запись в кеше (создана для агрегирования вермени жизни записи)
 type entry struct {
 	value      interface{}
@@ -18,6 +20,7 @@ type entry struct {
 // LocalCache - Предназначен для локального харнения данных с доступом на чтения
 // Хранит ряд таблиц распределеных по идентификаторам. В одном LocalCache может быть множество таблиц с разным идентификатором
 type LocalCache struct {
+	name   string        // Имя кеша в метриках
 	expire time.Duration // Для всех записей этого кеша будет применятся заданный промежуток протухания с даты создания записи
 	mt     *sync.Mutex
 	store  map[uint32]*sync.Map // список таблиц данных для конкретного кеша
@@ -27,7 +30,13 @@ type LocalCache struct {
 // принимает expire - время жизни каждой записи
 // и идентификаторы на основе которых создаются хранилища ключей-значений (аналог таблицы в БД)
 func GetNewCache(expire time.Duration, ids ...uint32) *LocalCache {
+	return GetNewNamedCache(localCacheMetricName, expire, ids...)
+}
+
+//GetNewNamedCache - то же, что GetNewCache, но попадания и промахи кеша учитываются в метриках под именем name
+func GetNewNamedCache(name string, expire time.Duration, ids ...uint32) *LocalCache {
 	cache := LocalCache{
+		name:   name,
 		store:  make(map[uint32]*sync.Map),
 		mt:     &sync.Mutex{},
 		expire: expire,
@@ -71,8 +80,10 @@ func (lc *LocalCache) GetItem(id uint32, key interface{}) interface{} {
 		if val, ok := cached.Load(key); ok {
 			if v, ok := val.(entry); ok {
 				if lc.expire != 0 && v.expireTime.Before(time.Now()) {
+					ObserveCache(lc.name, false)
 					return nil
 				}
+				ObserveCache(lc.name, true)
 				return v.value
 			}
 		}
@@ -80,5 +91,30 @@ func (lc *LocalCache) GetItem(id uint32, key interface{}) interface{} {
 		cached = &sync.Map{}
 		lc.store[id] = cached
 	}
+	ObserveCache(lc.name, false)
 	return nil
 }
+
+//DeleteItem - удаляет значение по ключу key из таблицы с идентификатором id
+func (lc *LocalCache) DeleteItem(id uint32, key interface{}) {
+	if cached, ok := lc.store[id]; ok && cached != nil {
+		cached.Delete(key)
+	}
+}
+
+//Sweep - удаляет из таблицы id протухшие записи, а также записи, для которых remove вернет true (remove может быть nil).
+// GetItem протухшие записи не удаляет, поэтому долгоживущие кеши нужно периодически чистить
+func (lc *LocalCache) Sweep(id uint32, remove func(key, value interface{}) bool) {
+	cached, ok := lc.store[id]
+	if !ok || cached == nil {
+		return
+	}
+	now := time.Now()
+	cached.Range(func(key, val interface{}) bool {
+		v, ok := val.(entry)
+		if !ok || lc.expire != 0 && v.expireTime.Before(now) || remove != nil && remove(key, v.value) {
+			cached.Delete(key)
+		}
+		return true
+	})
+}
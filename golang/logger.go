@@ -0,0 +1,233 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	retry "github.com/hashicorp/go-retryablehttp"
+)
+
+// LogLevel - уровень логирования
+type LogLevel int32
+
+const (
+	DebugLevel LogLevel = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case DebugLevel:
+		return "DEBUG"
+	case InfoLevel:
+		return "INFO"
+	case WarnLevel:
+		return "WARN"
+	case ErrorLevel:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int32(l))
+}
+
+// Logger - leveled structured logger for all library components.
+// keysAndValues is a list of key, value pairs. Request ID and user ID are taken from ctx automatically
+type Logger interface {
+	Debug(ctx context.Context, msg string, keysAndValues ...interface{})
+	Info(ctx context.Context, msg string, keysAndValues ...interface{})
+	Warn(ctx context.Context, msg string, keysAndValues ...interface{})
+	Error(ctx context.Context, msg string, keysAndValues ...interface{})
+}
+
+// NopLogger - logger that discards everything
+type NopLogger struct{}
+
+func (NopLogger) Debug(context.Context, string, ...interface{}) {}
+func (NopLogger) Info(context.Context, string, ...interface{})  {}
+func (NopLogger) Warn(context.Context, string, ...interface{})  {}
+func (NopLogger) Error(context.Context, string, ...interface{}) {}
+
+// printfLogger - formats records into a single line and sends them to printf like function
+type printfLogger struct {
+	level  *int32
+	printf func(string, ...interface{})
+}
+
+// NewStdLogger - adapter of the standard log.Logger. If l is nil log.Default() will be used
+func NewStdLogger(l *log.Logger, level LogLevel) Logger {
+	if l == nil {
+		l = log.Default()
+	}
+	lvl := int32(level)
+	return printfLogger{level: &lvl, printf: l.Printf}
+}
+
+// NewWrapperLogger - adapter of LoggerWrapper (any printf like logger)
+func NewWrapperLogger(w LoggerWrapper, level LogLevel) Logger {
+	lvl := int32(level)
+	return printfLogger{level: &lvl, printf: w.Printf}
+}
+
+// NewStringWriterLogger - adapter of io.StringWriter (the logger of the old middleware API)
+func NewStringWriterLogger(w io.StringWriter, level LogLevel) Logger {
+	lvl := int32(level)
+	return printfLogger{level: &lvl, printf: func(format string, args ...interface{}) {
+		w.WriteString(fmt.Sprintf(format, args...))
+	}}
+}
+
+// SetLevel - change minimal level of the logger created by NewStdLogger or NewWrapperLogger
+func SetLevel(l Logger, level LogLevel) {
+	if p, ok := l.(printfLogger); ok {
+		atomic.StoreInt32(p.level, int32(level))
+	}
+}
+
+func (p printfLogger) log(ctx context.Context, level LogLevel, msg string, keysAndValues []interface{}) {
+	if level < LogLevel(atomic.LoadInt32(p.level)) {
+		return
+	}
+	p.printf("%s", FormatLogRecord(ctx, level, msg, keysAndValues...))
+}
+
+func (p printfLogger) Debug(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	p.log(ctx, DebugLevel, msg, keysAndValues)
+}
+
+func (p printfLogger) Info(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	p.log(ctx, InfoLevel, msg, keysAndValues)
+}
+
+func (p printfLogger) Warn(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	p.log(ctx, WarnLevel, msg, keysAndValues)
+}
+
+func (p printfLogger) Error(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	p.log(ctx, ErrorLevel, msg, keysAndValues)
+}
+
+// ContextLogFields - return key value pairs that describe request in the context (request ID and user ID)
+func ContextLogFields(ctx context.Context) []interface{} {
+	if ctx == nil {
+		return nil
+	}
+	var fields []interface{}
+	if reqID, ok := ctx.Value(RequestID).(string); ok && len(reqID) != 0 {
+		fields = append(fields, "requestID", reqID)
+	}
+	if user, ok := ctx.Value(UserKey).(User); ok && user.ID != 0 {
+		fields = append(fields, "userID", user.ID)
+	}
+	return fields
+}
+
+// FormatLogRecord - format record as line: level=INFO msg="text" requestID=... userID=... key=value
+func FormatLogRecord(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{}) string {
+	var b strings.Builder
+	b.WriteString("level=")
+	b.WriteString(level.String())
+	b.WriteString(" msg=")
+	b.WriteString(fmt.Sprintf("%q", msg))
+	writeFields(&b, ContextLogFields(ctx))
+	writeFields(&b, keysAndValues)
+	return b.String()
+}
+
+func writeFields(b *strings.Builder, keysAndValues []interface{}) {
+	for i := 0; i < len(keysAndValues); i += 2 {
+		b.WriteByte(' ')
+		b.WriteString(fmt.Sprint(keysAndValues[i]))
+		b.WriteByte('=')
+		if i+1 >= len(keysAndValues) {
+			b.WriteString("MISSING")
+			break
+		}
+		val := fieldString(keysAndValues[i+1])
+		if strings.ContainsAny(val, " \t\n\"=") || len(val) == 0 {
+			val = fmt.Sprintf("%q", val)
+		}
+		b.WriteString(val)
+	}
+}
+
+// fieldString - value of the field. Error and String of typed nil pointers can panic, they are printed as <nil>
+func fieldString(v interface{}) (val string) {
+	defer func() {
+		if r := recover(); r != nil {
+			val = fmt.Sprintf("<PANIC=%v>", r)
+		}
+	}()
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return "<nil>"
+	}
+	switch v := v.(type) {
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// retryLogger - adapter Logger to retryablehttp.LeveledLogger
+type retryLogger struct {
+	log Logger
+	ctx context.Context
+}
+
+// NewRetryLogger - adapter to the retryablehttp.LeveledLogger. Use it as retry.Client.Logger.
+// DoRequest binds it to the context of the request, so records contain request ID
+func NewRetryLogger(l Logger) retry.LeveledLogger {
+	return retryLogger{log: l, ctx: context.Background()}
+}
+
+// withContext - the same logger with context of the request
+func (r retryLogger) withContext(ctx context.Context) retryLogger {
+	r.ctx = ctx
+	return r
+}
+
+func (r retryLogger) Error(msg string, keysAndValues ...interface{}) {
+	r.log.Error(r.ctx, msg, keysAndValues...)
+}
+
+func (r retryLogger) Info(msg string, keysAndValues ...interface{}) {
+	r.log.Info(r.ctx, msg, keysAndValues...)
+}
+
+func (r retryLogger) Debug(msg string, keysAndValues ...interface{}) {
+	r.log.Debug(r.ctx, msg, keysAndValues...)
+}
+
+func (r retryLogger) Warn(msg string, keysAndValues ...interface{}) {
+	r.log.Warn(r.ctx, msg, keysAndValues...)
+}
+
+var libLogger atomic.Value
+
+func init() {
+	libLogger.Store(loggerHolder{NopLogger{}})
+}
+
+type loggerHolder struct {
+	Logger
+}
+
+// SetLogger - set logger for all library components (DoRequest, middlewares and so on)
+func SetLogger(l Logger) {
+	if l == nil {
+		l = NopLogger{}
+	}
+	libLogger.Store(loggerHolder{l})
+}
+
+// GetLogger - return logger of the library
+func GetLogger() Logger {
+	return libLogger.Load().(loggerHolder).Logger
+}
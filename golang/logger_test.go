@@ -0,0 +1,55 @@
+package golang
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type nilStringer struct{ name string }
+
+func (s *nilStringer) String() string { return s.name }
+
+type nilError struct{ msg string }
+
+func (e *nilError) Error() string { return e.msg }
+
+func TestFormatLogRecord(t *testing.T) {
+	ctx := context.WithValue(context.Background(), RequestID, "req-1")
+	var s *nilStringer
+	var e *nilError
+	cases := []struct {
+		name string
+		kv   []interface{}
+		want string
+	}{
+		{"plain", []interface{}{"a", 1}, `level=INFO msg="m" requestID=req-1 a=1`},
+		{"quoted", []interface{}{"a", "x y"}, `level=INFO msg="m" requestID=req-1 a="x y"`},
+		{"missing", []interface{}{"a"}, `level=INFO msg="m" requestID=req-1 a=MISSING`},
+		{"nil stringer", []interface{}{"s", s}, `level=INFO msg="m" requestID=req-1 s=<nil>`},
+		{"nil error", []interface{}{"e", e}, `level=INFO msg="m" requestID=req-1 e=<nil>`},
+		{"error", []interface{}{"e", &nilError{"bad"}}, `level=INFO msg="m" requestID=req-1 e=bad`},
+	}
+	for _, c := range cases {
+		if got := FormatLogRecord(ctx, InfoLevel, "m", c.kv...); got != c.want {
+			t.Errorf("%s: got %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+type lines []string
+
+func (l *lines) WriteString(s string) (int, error) {
+	*l = append(*l, s)
+	return len(s), nil
+}
+
+func TestRetryLoggerContext(t *testing.T) {
+	var out lines
+	l := NewRetryLogger(NewStringWriterLogger(&out, DebugLevel)).(retryLogger)
+	l.Debug("no context")
+	l.withContext(context.WithValue(context.Background(), RequestID, "req-2")).Warn("retry", "attempt", 1)
+	if len(out) != 2 || strings.Contains(out[0], "requestID") || !strings.Contains(out[1], "requestID=req-2 attempt=1") {
+		t.Fatalf("unexpected records %q", out)
+	}
+}
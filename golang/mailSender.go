@@ -0,0 +1,130 @@
+package golang
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// MailMessage - письмо пользователю
+type MailMessage struct {
+	To      []string
+	Subject string
+	Body    string
+	HTML    bool // Body содержит html
+}
+
+// MailSender - отправка писем (подтверждение почты, восстановление пароля и т.д.)
+type MailSender interface {
+	Send(ctx context.Context, msg MailMessage) error
+}
+
+// SMTPSender - отправка писем через SMTP сервер.
+// Если сервер поддерживает STARTTLS, соединение будет зашифровано
+type SMTPSender struct {
+	Addr      string      // host:port
+	From      string      // адрес отправителя
+	Auth      smtp.Auth   // nil - без авторизации
+	TLSConfig *tls.Config // nil - проверка сертификата по имени хоста из Addr
+	Timeout   time.Duration
+}
+
+// NewSMTPSender - sender with PLAIN authentication if user is not empty
+func NewSMTPSender(addr, from, user, password string) *SMTPSender {
+	s := &SMTPSender{Addr: addr, From: from, Timeout: 30 * time.Second}
+	if len(user) != 0 {
+		host, _, _ := net.SplitHostPort(addr)
+		s.Auth = smtp.PlainAuth("", user, password, host)
+	}
+	return s
+}
+
+// buildMessage - RFC 5322 message with utf-8 subject and body
+func (s *SMTPSender) buildMessage(msg MailMessage) []byte {
+	var b strings.Builder
+	contentType := "text/plain"
+	if msg.HTML {
+		contentType = "text/html"
+	}
+	fmt.Fprintf(&b, "From: %s\r\n", s.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg MailMessage) error {
+	if len(msg.To) == 0 {
+		return errors.New("mail without recipients")
+	}
+	for _, addr := range append([]string{s.From}, msg.To...) {
+		if strings.ContainsAny(addr, "\r\n") {
+			return Errors["badEmail"]
+		}
+	}
+	if s.Timeout > 0 {
+		var cncl context.CancelFunc
+		ctx, cncl = context.WithTimeout(ctx, s.Timeout)
+		defer cncl()
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	host, _, _ := net.SplitHostPort(s.Addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		cfg := s.TLSConfig
+		if cfg == nil {
+			cfg = &tls.Config{ServerName: host}
+		}
+		if err = client.StartTLS(cfg); err != nil {
+			return err
+		}
+	}
+	if s.Auth != nil {
+		if err = client.Auth(s.Auth); err != nil {
+			return err
+		}
+	}
+	if err = client.Mail(s.From); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		if err = client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(s.buildMessage(msg)); err != nil {
+		w.Close()
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
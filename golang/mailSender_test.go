@@ -0,0 +1,113 @@
+package golang
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTP - минимальный SMTP сервер без STARTTLS, запоминает одну сессию
+type fakeSMTP struct {
+	ln       net.Listener
+	commands []string
+	data     string
+	done     chan struct{}
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSMTP{ln: ln, done: make(chan struct{})}
+	t.Cleanup(func() { ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTP) serve() {
+	defer close(s.done)
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		s.commands = append(s.commands, line)
+		switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+		case "EHLO":
+			tp.PrintfLine("250-localhost")
+			tp.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			tp.PrintfLine("235 ok")
+		case "MAIL", "RCPT":
+			tp.PrintfLine("250 ok")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.data = string(data)
+			tp.PrintfLine("250 queued")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 unknown command")
+		}
+	}
+}
+
+func TestSMTPSenderSend(t *testing.T) {
+	srv := newFakeSMTP(t)
+	s := NewSMTPSender(srv.ln.Addr().String(), "noreply@egeon.ua", "user", "secret")
+	err := s.Send(context.Background(), MailMessage{
+		To:      []string{"a@egeon.ua", "b@egeon.ua"},
+		Subject: "Підтвердження пошти",
+		Body:    "line1\nline2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-srv.done
+
+	auth := base64.StdEncoding.EncodeToString([]byte("\x00user\x00secret"))
+	want := []string{"AUTH PLAIN " + auth, "MAIL FROM:<noreply@egeon.ua>", "RCPT TO:<a@egeon.ua>", "RCPT TO:<b@egeon.ua>", "DATA", "QUIT"}
+	got := srv.commands[1:]
+	if len(got) != len(want) {
+		t.Fatalf("commands %q", srv.commands)
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i], want[i]) {
+			t.Errorf("command %d: %q, want %q", i, got[i], want[i])
+		}
+	}
+	for _, h := range []string{"From: noreply@egeon.ua\n", "To: a@egeon.ua, b@egeon.ua\n", "Subject: =?utf-8?q?", "Content-Type: text/plain; charset=utf-8\n", "\nline1\nline2\n"} {
+		if !strings.Contains(srv.data, h) {
+			t.Errorf("message has no %q:\n%s", h, srv.data)
+		}
+	}
+}
+
+func TestSMTPSenderRejectsHeaderInjection(t *testing.T) {
+	s := NewSMTPSender("127.0.0.1:1", "noreply@egeon.ua", "", "")
+	err := s.Send(context.Background(), MailMessage{To: []string{"a@egeon.ua\r\nBcc: x@evil.com"}})
+	if err != Errors["badEmail"] {
+		t.Fatalf("got %v", err)
+	}
+	if err = s.Send(context.Background(), MailMessage{}); err == nil {
+		t.Fatal("mail without recipients is sent")
+	}
+}
@@ -0,0 +1,388 @@
+package golang
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type metricKind int
+
+const (
+	counterKind metricKind = iota
+	gaugeKind
+	histogramKind
+)
+
+func (k metricKind) String() string {
+	switch k {
+	case counterKind:
+		return "counter"
+	case gaugeKind:
+		return "gauge"
+	}
+	return "histogram"
+}
+
+// DefaultBuckets - buckets of latency histograms in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// atomicFloat - float64 that can be changed atomically
+type atomicFloat uint64
+
+func (f *atomicFloat) add(delta float64) {
+	for {
+		old := atomic.LoadUint64((*uint64)(f))
+		val := math.Float64bits(math.Float64frombits(old) + delta)
+		if atomic.CompareAndSwapUint64((*uint64)(f), old, val) {
+			return
+		}
+	}
+}
+
+func (f *atomicFloat) set(v float64) {
+	atomic.StoreUint64((*uint64)(f), math.Float64bits(v))
+}
+
+func (f *atomicFloat) load() float64 {
+	return math.Float64frombits(atomic.LoadUint64((*uint64)(f)))
+}
+
+// series - one set of label values of the metric
+type series struct {
+	labelValues []string
+	value       atomicFloat // counter and gauge value, sum for histogram
+	count       uint64      // histogram observations count
+	buckets     []uint64    // histogram counts per bucket (not cumulative)
+}
+
+// metricFamily - metric with name and all its series
+type metricFamily struct {
+	name       string
+	help       string
+	kind       metricKind
+	labelNames []string
+	bounds     []float64
+	mt         sync.RWMutex
+	series     map[string]*series
+}
+
+func (m *metricFamily) delete(labelValues []string) {
+	m.mt.Lock()
+	delete(m.series, strings.Join(labelValues, "\xff"))
+	m.mt.Unlock()
+}
+
+func (m *metricFamily) get(labelValues []string) *series {
+	key := strings.Join(labelValues, "\xff")
+	m.mt.RLock()
+	s, ok := m.series[key]
+	m.mt.RUnlock()
+	if ok {
+		return s
+	}
+	m.mt.Lock()
+	defer m.mt.Unlock()
+	if s, ok = m.series[key]; ok {
+		return s
+	}
+	values := make([]string, len(m.labelNames))
+	copy(values, labelValues)
+	s = &series{labelValues: values}
+	if m.kind == histogramKind {
+		s.buckets = make([]uint64, len(m.bounds))
+	}
+	m.series[key] = s
+	return s
+}
+
+// MetricsRegistry - набор метрик сервиса, которые отдаются в текстовом формате Prometheus
+type MetricsRegistry struct {
+	mt        sync.RWMutex
+	families  map[string]*metricFamily
+	onCollect []func()
+}
+
+// NewMetricsRegistry - create empty metrics registry
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{families: make(map[string]*metricFamily)}
+}
+
+func (r *MetricsRegistry) register(name, help string, kind metricKind, bounds []float64, labels []string) *metricFamily {
+	r.mt.Lock()
+	defer r.mt.Unlock()
+	if m, ok := r.families[name]; ok {
+		return m
+	}
+	m := &metricFamily{name: name, help: help, kind: kind, labelNames: labels, bounds: bounds, series: make(map[string]*series)}
+	r.families[name] = m
+	return m
+}
+
+// OnCollect - fn will be called before each export of metrics. Use it to update gauges from external sources
+func (r *MetricsRegistry) OnCollect(fn func()) {
+	r.mt.Lock()
+	r.onCollect = append(r.onCollect, fn)
+	r.mt.Unlock()
+}
+
+// CounterVec - counter metric with labels
+type CounterVec struct{ m *metricFamily }
+
+// NewCounter - register counter. If metric with the same name exists it will be returned
+func (r *MetricsRegistry) NewCounter(name, help string, labels ...string) CounterVec {
+	return CounterVec{r.register(name, help, counterKind, nil, labels)}
+}
+
+// Add - add v (must be positive) to the counter with labelValues
+func (c CounterVec) Add(v float64, labelValues ...string) {
+	c.m.get(labelValues).value.add(v)
+}
+
+// Inc - increment counter with labelValues
+func (c CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// GaugeVec - gauge metric with labels
+type GaugeVec struct{ m *metricFamily }
+
+// NewGauge - register gauge. If metric with the same name exists it will be returned
+func (r *MetricsRegistry) NewGauge(name, help string, labels ...string) GaugeVec {
+	return GaugeVec{r.register(name, help, gaugeKind, nil, labels)}
+}
+
+// Add - add delta to the gauge with labelValues
+func (g GaugeVec) Add(delta float64, labelValues ...string) {
+	g.m.get(labelValues).value.add(delta)
+}
+
+// Set - set gauge with labelValues
+func (g GaugeVec) Set(v float64, labelValues ...string) {
+	g.m.get(labelValues).value.set(v)
+}
+
+// Delete - remove the gauge series with labelValues from export
+func (g GaugeVec) Delete(labelValues ...string) {
+	g.m.delete(labelValues)
+}
+
+// HistogramVec - histogram metric with labels
+type HistogramVec struct{ m *metricFamily }
+
+// NewHistogram - register histogram with upper bounds of buckets. If buckets is nil DefaultBuckets will be used
+func (r *MetricsRegistry) NewHistogram(name, help string, buckets []float64, labels ...string) HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	bounds := make([]float64, len(buckets))
+	copy(bounds, buckets)
+	sort.Float64s(bounds)
+	return HistogramVec{r.register(name, help, histogramKind, bounds, labels)}
+}
+
+// Observe - add observation v to the histogram with labelValues
+func (h HistogramVec) Observe(v float64, labelValues ...string) {
+	s := h.m.get(labelValues)
+	if i := sort.SearchFloat64s(h.m.bounds, v); i < len(s.buckets) {
+		atomic.AddUint64(&s.buckets[i], 1)
+	}
+	atomic.AddUint64(&s.count, 1)
+	s.value.add(v)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func writeLabels(w *bufio.Writer, names, values []string, extraName, extraValue string) {
+	if len(names) == 0 && len(extraName) == 0 {
+		return
+	}
+	w.WriteByte('{')
+	for i := range names {
+		if i != 0 {
+			w.WriteByte(',')
+		}
+		w.WriteString(names[i])
+		w.WriteString(`="`)
+		w.WriteString(labelEscaper.Replace(values[i]))
+		w.WriteByte('"')
+	}
+	if len(extraName) != 0 {
+		if len(names) != 0 {
+			w.WriteByte(',')
+		}
+		w.WriteString(extraName)
+		w.WriteString(`="`)
+		w.WriteString(extraValue)
+		w.WriteByte('"')
+	}
+	w.WriteByte('}')
+}
+
+func (m *metricFamily) write(w *bufio.Writer) {
+	m.mt.RLock()
+	all := make([]*series, 0, len(m.series))
+	for _, s := range m.series {
+		all = append(all, s)
+	}
+	m.mt.RUnlock()
+	sort.Slice(all, func(i, j int) bool {
+		return strings.Join(all[i].labelValues, "\xff") < strings.Join(all[j].labelValues, "\xff")
+	})
+	w.WriteString("# HELP " + m.name + " " + strings.ReplaceAll(m.help, "\n", `\n`) + "\n")
+	w.WriteString("# TYPE " + m.name + " " + m.kind.String() + "\n")
+	for _, s := range all {
+		if m.kind != histogramKind {
+			w.WriteString(m.name)
+			writeLabels(w, m.labelNames, s.labelValues, "", "")
+			w.WriteString(" " + formatFloat(s.value.load()) + "\n")
+			continue
+		}
+		var cumulative uint64
+		for i, bound := range m.bounds {
+			cumulative += atomic.LoadUint64(&s.buckets[i])
+			w.WriteString(m.name + "_bucket")
+			writeLabels(w, m.labelNames, s.labelValues, "le", formatFloat(bound))
+			w.WriteString(" " + strconv.FormatUint(cumulative, 10) + "\n")
+		}
+		count := atomic.LoadUint64(&s.count)
+		w.WriteString(m.name + "_bucket")
+		writeLabels(w, m.labelNames, s.labelValues, "le", "+Inf")
+		w.WriteString(" " + strconv.FormatUint(count, 10) + "\n")
+		w.WriteString(m.name + "_sum")
+		writeLabels(w, m.labelNames, s.labelValues, "", "")
+		w.WriteString(" " + formatFloat(s.value.load()) + "\n")
+		w.WriteString(m.name + "_count")
+		writeLabels(w, m.labelNames, s.labelValues, "", "")
+		w.WriteString(" " + strconv.FormatUint(count, 10) + "\n")
+	}
+}
+
+// WriteText - write all metrics in the Prometheus text exposition format
+func (r *MetricsRegistry) WriteText(out io.Writer) error {
+	r.mt.RLock()
+	hooks := r.onCollect
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	r.mt.RUnlock()
+	for _, fn := range hooks {
+		fn()
+	}
+	sort.Strings(names)
+	w := bufio.NewWriter(out)
+	for _, name := range names {
+		r.mt.RLock()
+		m := r.families[name]
+		r.mt.RUnlock()
+		m.write(w)
+	}
+	return w.Flush()
+}
+
+// Metrics - стандартные метрики библиотеки
+type Metrics struct {
+	Registry         *MetricsRegistry
+	Requests         CounterVec   // route, method, status
+	RequestDuration  HistogramVec // route, method, status
+	InFlight         GaugeVec
+	Cache            CounterVec // cache, result (hit or miss)
+	Outbound         CounterVec // host, method, status
+	OutboundRetries  CounterVec // host
+	OutboundDuration HistogramVec
+}
+
+// NewMetrics - create registry with standard metrics of the library
+func NewMetrics() *Metrics {
+	r := NewMetricsRegistry()
+	return &Metrics{
+		Registry:         r,
+		Requests:         r.NewCounter("http_requests_total", "Count of handled HTTP requests", "route", "method", "status"),
+		RequestDuration:  r.NewHistogram("http_request_duration_seconds", "Latency of handled HTTP requests", nil, "route", "method", "status"),
+		InFlight:         r.NewGauge("http_requests_in_flight", "Count of HTTP requests in progress"),
+		Cache:            r.NewCounter("cache_requests_total", "Cache lookups by result", "cache", "result"),
+		Outbound:         r.NewCounter("outbound_requests_total", "Requests made by DoRequest", "host", "method", "status"),
+		OutboundRetries:  r.NewCounter("outbound_request_retries_total", "Retries made by DoRequest", "host"),
+		OutboundDuration: r.NewHistogram("outbound_request_duration_seconds", "Latency of requests made by DoRequest", nil, "host", "method"),
+	}
+}
+
+// DefaultMetrics - metrics used by all library components
+var DefaultMetrics = NewMetrics()
+
+// ObserveCache - count cache lookup with name cache
+func ObserveCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	DefaultMetrics.Cache.Inc(cache, result)
+}
+
+func (m *Metrics) observeRequest(route, method string, status int, start time.Time) {
+	code := strconv.Itoa(status)
+	m.Requests.Inc(route, method, code)
+	m.RequestDuration.Observe(time.Since(start).Seconds(), route, method, code)
+}
+
+// MetricsMiddleware - gin middleware that count requests and latency by route template, method and status
+func (m *Metrics) MetricsMiddleware(c *gin.Context) {
+	start := time.Now()
+	m.InFlight.Add(1)
+	defer m.InFlight.Add(-1)
+	c.Next()
+	route := c.FullPath()
+	if len(route) == 0 {
+		route = "unmatched"
+	}
+	m.observeRequest(route, c.Request.Method, c.Writer.Status(), start)
+}
+
+// MetricsHTTPMiddleware - net/http middleware that count requests and latency. route - route template of the handler
+func (m *Metrics) MetricsHTTPMiddleware(route string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		m.InFlight.Add(1)
+		defer m.InFlight.Add(-1)
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		handler.ServeHTTP(sw, r)
+		m.observeRequest(route, r.Method, sw.status, start)
+	})
+}
+
+// MetricsHandler - return handler that export metrics in the Prometheus text format
+func (m *Metrics) MetricsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		m.Registry.WriteText(w)
+	}
+}
+
+// AddMetricsHandler - add DefaultMetrics middleware to the router and export metrics by url
+func AddMetricsHandler(router gin.IRoutes, url string) {
+	router.Use(DefaultMetrics.MetricsMiddleware)
+	router.GET(url, gin.WrapF(DefaultMetrics.MetricsHandler()))
+}
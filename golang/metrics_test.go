@@ -0,0 +1,85 @@
+package golang
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsWriteText(t *testing.T) {
+	r := NewMetricsRegistry()
+	c := r.NewCounter("requests_total", "Count of\nrequests", "path")
+	c.Inc(`/a"b\c` + "\nd")
+	c.Add(2, "/")
+	g := r.NewGauge("in_flight", "In flight")
+	g.Set(3)
+	g.Add(-1)
+	h := r.NewHistogram("latency_seconds", "Latency", []float64{1, 0.5}, "method")
+	h.Observe(0.5, "GET")
+	h.Observe(0.7, "GET")
+	h.Observe(2, "GET")
+	collected := 0
+	r.OnCollect(func() { collected++ })
+
+	var out strings.Builder
+	if err := r.WriteText(&out); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP in_flight In flight
+# TYPE in_flight gauge
+in_flight 2
+# HELP latency_seconds Latency
+# TYPE latency_seconds histogram
+latency_seconds_bucket{method="GET",le="0.5"} 1
+latency_seconds_bucket{method="GET",le="1"} 2
+latency_seconds_bucket{method="GET",le="+Inf"} 3
+latency_seconds_sum{method="GET"} 3.2
+latency_seconds_count{method="GET"} 3
+# HELP requests_total Count of\nrequests
+# TYPE requests_total counter
+requests_total{path="/"} 2
+requests_total{path="/a\"b\\c\nd"} 1
+`
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+	if collected != 1 {
+		t.Errorf("collect hooks called %d times", collected)
+	}
+}
+
+func TestMetricsRegisterSameName(t *testing.T) {
+	r := NewMetricsRegistry()
+	r.NewCounter("c", "help", "l").Inc("x")
+	r.NewCounter("c", "help", "l").Inc("x")
+	if v := counterValue(r.NewCounter("c", "help", "l"), "x"); v != 2 {
+		t.Errorf("counter %v", v)
+	}
+}
+
+func TestMetricsHTTPMiddleware(t *testing.T) {
+	m := NewMetrics()
+	handler := m.MetricsHTTPMiddleware("/items/:id", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/1", nil))
+	if v := counterValue(m.Requests, "/items/:id", http.MethodGet, "404"); v != 1 {
+		t.Errorf("requests %v", v)
+	}
+	rec := httptest.NewRecorder()
+	m.MetricsHandler()(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE http_request_duration_seconds histogram",
+		`http_request_duration_seconds_count{route="/items/:id",method="GET",status="404"} 1`,
+		"http_requests_in_flight 0",
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("no %q in\n%s", line, body)
+		}
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("content type %s", ct)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"os"
 	"runtime"
@@ -29,30 +28,11 @@ func ContextMiddleware(c *gin.Context) {
 func AddServerStatsHandler(router gin.IRoutes, url string, info *ServerInfo, checkService func() error) {
 	var mem runtime.MemStats
 	var stats = ServerStatus{StartDate: time.Now(), Info: *info}
+	reqStats := NewRequestStats()
 	router.Use(func(c *gin.Context) {
-		method := c.Request.Method
 		reqStart := time.Now()
 		c.Next()
-		var successCnt, failedCnt uint64
-		reqStop := time.Now().Sub(reqStart).Milliseconds()
-		if !c.IsAborted() {
-			successCnt = atomic.AddUint64(&stats.SuccesReqCnt, 1)
-		} else {
-			failedCnt = atomic.AddUint64(&stats.FaileReqCnt, 1)
-			switch method {
-			case http.MethodGet:
-				atomic.AddUint64(&stats.FaileGetCnt, 1)
-			case http.MethodPost:
-				atomic.AddUint64(&stats.FailePostCnt, 1)
-			case http.MethodPut:
-				atomic.AddUint64(&stats.FailePutCnt, 1)
-			case http.MethodDelete:
-				atomic.AddUint64(&stats.FaileDelCnt, 1)
-			}
-		}
-		allCnt := successCnt + failedCnt
-		middleReqTime := atomic.LoadInt64(&stats.MiddleReqTime)
-		atomic.StoreInt64(&stats.MiddleReqTime, int64((uint64(middleReqTime)*(allCnt-1)+uint64(reqStop))/allCnt))
+		reqStats.Observe(c.Request.Method, c.Writer.Status(), c.IsAborted(), time.Since(reqStart))
 	})
 
 	router.GET(url, func(c *gin.Context) {
@@ -70,22 +50,25 @@ func AddServerStatsHandler(router gin.IRoutes, url string, info *ServerInfo, che
 			"freesObject":  mem.Frees,
 			"activeObject": mem.Mallocs - mem.Frees,
 		}
+		if pools := DBPoolsStats(); pools != nil {
+			tempStats.Addition["dbPools"] = pools
+		}
 		tempStats.UpTime = time.Now().Sub(stats.StartDate)
 		tempStats.UpTimeStr = tempStats.UpTime.String()
-		tempStats.SuccesReqCnt = atomic.LoadUint64(&stats.SuccesReqCnt)
-		tempStats.FaileReqCnt = atomic.LoadUint64(&stats.FaileReqCnt)
-		tempStats.FaileGetCnt = atomic.LoadUint64(&stats.FaileGetCnt)
-		tempStats.FailePostCnt = atomic.LoadUint64(&stats.FailePostCnt)
-		tempStats.FailePutCnt = atomic.LoadUint64(&stats.FailePutCnt)
-		tempStats.FaileDelCnt = atomic.LoadUint64(&stats.FaileDelCnt)
-		tempStats.MiddleReqTime = atomic.LoadInt64(&stats.MiddleReqTime)
+		reqStats.Fill(&tempStats)
 		c.JSON(http.StatusOK, tempStats)
 	})
 }
 
+// GetServerStatusHandler - net/http handler of the service status without request statistics (see GetServerStatsHandler)
 func GetServerStatusHandler(status ServerStatus, nowConnected *int32, checkService func() error) http.HandlerFunc {
-	status.StartDate = time.Now()
-	var mem runtime.MemStats
+	return GetServerStatsHandler(status, nowConnected, checkService, nil)
+}
+
+// GetServerStatsHandler - net/http аналог AddServerStatsHandler.
+// reqStats заполняется RequestStatsHTTPMiddleware, nil - статистика запросов не отдается
+func GetServerStatsHandler(info ServerStatus, nowConnected *int32, checkService func() error, reqStats *RequestStats) http.HandlerFunc {
+	info.StartDate = time.Now()
 	return func(w http.ResponseWriter, r *http.Request) {
 		if err := checkService(); err != nil {
 			w.Header().Add("Content-Type", "text/plain")
@@ -93,7 +76,9 @@ func GetServerStatusHandler(status ServerStatus, nowConnected *int32, checkServi
 			w.Write([]byte(err.Error()))
 			return
 		}
+		var mem runtime.MemStats
 		runtime.ReadMemStats(&mem)
+		status := info
 		status.Addition = map[string]interface{}{
 			"memmory":      mem.HeapAlloc / (1024 * 1024),
 			"allObjects":   mem.Mallocs,
@@ -103,8 +88,14 @@ func GetServerStatusHandler(status ServerStatus, nowConnected *int32, checkServi
 			"cpu":          runtime.NumCPU(),
 			"activeObject": mem.Mallocs - mem.Frees,
 		}
+		if pools := DBPoolsStats(); pools != nil {
+			status.Addition["dbPools"] = pools
+		}
 		status.UpTime = time.Now().Sub(status.StartDate)
-		status.UpTimeStr = time.Now().Sub(status.StartDate).String()
+		status.UpTimeStr = status.UpTime.String()
+		if reqStats != nil {
+			reqStats.Fill(&status)
+		}
 
 		w.Header().Add("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -112,12 +103,23 @@ func GetServerStatusHandler(status ServerStatus, nowConnected *int32, checkServi
 	}
 }
 
+// RequestStatsHTTPMiddleware - collect statistics of the requests for GetServerStatsHandler
+func RequestStatsHTTPMiddleware(reqStats *RequestStats, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		handler.ServeHTTP(sw, r)
+		reqStats.Observe(r.Method, sw.status, false, time.Since(start))
+	})
+}
+
 // FormRequestID - формирует строку с идентификатором запроса
+// Deprecated: идентификатор больше не содержит email и ключ сессии пользователя, используйте NewRequestID
 func FormRequestID(user *User) string {
 	if user == nil {
 		return ""
 	}
-	return fmt.Sprintf("%d:%s:%s", time.Now().UnixNano(), user.Email, user.SessionKey)
+	return NewRequestID()
 }
 
 // CreateSignature - подписывает через secret пользователя userJSON
@@ -147,18 +149,27 @@ func ParseHeader(r *http.Request) (context.Context, error) {
 	if !CheckSignature(signStr, userJSON, secret) {
 		return r.Context(), EgeonError{Code: NotAuthError, Description: "Signature for user is incorrect"}
 	}
-	var user User
-	if err := json.Unmarshal([]byte(userJSON), &user); err != nil {
+	principal, err := parsePrincipal([]byte(userJSON))
+	if err != nil {
 		return r.Context(), EgeonError{Code: NotAuthError, Description: "Error when try parse user in header " + err.Error()}
 	}
-	ctx := context.WithValue(r.Context(), UserKey, user)
-	requestID := r.Header.Get(RequestIDHeaderKey)
+	ctx := context.WithValue(r.Context(), PrincipalKey, principal)
+	ctx = context.WithValue(ctx, UserKey, principal.User())
+	ctx = withLazyUser(ctx)
+	requestID, _ := r.Context().Value(RequestID).(string) // assigned by RequestIDMiddleware
 	if len(requestID) == 0 {
-		requestID = FormRequestID(&user)
+		requestID = r.Header.Get(RequestIDHeaderKey)
+	}
+	requestID, ok := checkRequestID(r.Context(), requestID)
+	if !ok {
+		// ParseHeader проверяет пользователя, а не идентификатор: некорректный ID заменяется (отклоняет его RequestIDMiddleware)
+		GetLogger().Warn(r.Context(), "malformed request ID replaced", "requestID", r.Header.Get(RequestIDHeaderKey))
+		requestID = NewRequestID()
 	}
 	ctx = context.WithValue(ctx, RequestID, requestID)
 	ctx = context.WithValue(ctx, SignKey, signStr)
 	ctx = context.WithValue(ctx, AllowedRoleKey, allowedRole)
+	noteAccessLogContext(ctx)
 	return ctx, nil
 }
 
@@ -180,13 +191,18 @@ func ParseHTTPHeaderMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx, err := ParseHeader(r)
 		if err != nil {
-			data, _ := json.Marshal(err)
-			w.Header().Add("Content-Type", "application/json")
-			w.WriteHeader(http.StatusForbidden)
-			w.Write(data)
+			writeJSON(w, http.StatusForbidden, err)
 			return
 		}
 		r = r.WithContext(ctx)
 		handler.ServeHTTP(w, r)
 	})
 }
+
+// writeJSON - write value as json response with status code
+func writeJSON(w http.ResponseWriter, code int, value interface{}) {
+	data, _ := json.Marshal(value)
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/blabu/egeonLib/golang"
+	"github.com/go-redis/redis/v8"
+)
+
+// AuditStream - история изменений в redis streams. Каждая запись добавляется в общий поток stream
+// (для внешних потребителей) и в поток сущности stream:<entityType>:<entityID>, по которому читается история
+type AuditStream struct {
+	cache  Model
+	stream string
+	maxLen int64
+}
+
+// NewAuditStream - create redis audit store. maxLen - approximate max length of each stream (0 - unlimited)
+func NewAuditStream(cache Model, stream string, maxLen int64) *AuditStream {
+	return &AuditStream{cache: cache, stream: stream, maxLen: maxLen}
+}
+
+func (s *AuditStream) entityStream(entityType string, entityID uint64) string {
+	return s.stream + ":" + entityType + ":" + strconv.FormatUint(entityID, 10)
+}
+
+func (s *AuditStream) WriteAudit(ctx context.Context, records []golang.AuditRecord) error {
+	if s.cache.storage == nil {
+		return errors.New("cache is nil")
+	}
+	pipe := s.cache.storage.Pipeline()
+	for i := range records {
+		data, err := records[i].MarshalJSON()
+		if err != nil {
+			return err
+		}
+		values := map[string]interface{}{"audit": data}
+		pipe.XAdd(ctx, &redis.XAddArgs{Stream: s.stream, MaxLenApprox: s.maxLen, Values: values})
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream:       s.entityStream(records[i].EntityType, records[i].EntityID),
+			MaxLenApprox: s.maxLen,
+			Values:       values,
+		})
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// streamMillis - stream id bound of the time
+func streamMillis(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+}
+
+func (s *AuditStream) History(ctx context.Context, q golang.AuditQuery) ([]golang.AuditRecord, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	if s.cache.storage == nil {
+		return nil, errors.New("cache is nil")
+	}
+	start, end := "-", "+"
+	if !q.Since.IsZero() {
+		start = streamMillis(q.Since)
+	}
+	if !q.Until.IsZero() {
+		end = "(" + streamMillis(q.Until) // исключая верхнюю границу (redis >= 6.2)
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = golang.DefaultAuditLimit
+	}
+	msgs, err := s.cache.storage.XRevRangeN(ctx, s.entityStream(q.EntityType, q.EntityID), end, start, int64(q.Offset+limit)).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]golang.AuditRecord, 0, limit)
+	for i := q.Offset; i < len(msgs); i++ {
+		data, ok := msgs[i].Values["audit"].(string)
+		if !ok {
+			continue
+		}
+		var r golang.AuditRecord
+		if err = r.UnmarshalJSON([]byte(data)); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blabu/egeonLib/golang"
+)
+
+func TestAuditStreamNegativeOffset(t *testing.T) {
+	s := NewAuditStream(Model{}, "audit", 0)
+	if _, err := s.History(context.Background(), golang.AuditQuery{EntityType: "user", EntityID: 1, Offset: -1}); err == nil {
+		t.Fatal("negative offset accepted")
+	}
+}
+
+func TestAuditStreamHistory(t *testing.T) {
+	s := NewAuditStream(testModel(t), "audit", 0)
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour).UTC()
+	for i := 0; i < 5; i++ {
+		rec := golang.AuditRecord{EntityType: "user", EntityID: 1, Action: golang.Updated, AddedDate: start.Add(time.Duration(i) * time.Minute),
+			Changes: []golang.FieldChange{{Field: "email", After: string(rune('a' + i))}}}
+		if err := s.WriteAudit(ctx, []golang.AuditRecord{rec}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := s.History(ctx, golang.AuditQuery{EntityType: "user", EntityID: 1, Limit: 2, Offset: 1})
+	if err != nil || len(res) != 2 || res[0].Changes[0].After != "d" || res[1].Changes[0].After != "c" {
+		t.Fatalf("history %+v, %v", res, err)
+	}
+	if res, _ = s.History(ctx, golang.AuditQuery{EntityType: "user", EntityID: 2}); len(res) != 0 {
+		t.Fatalf("history of other entity %+v", res)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/blabu/egeonLib/golang"
 	"github.com/go-redis/redis/v8"
 )
 
@@ -46,6 +47,19 @@ func (m Model) Save(ctx context.Context) {
 	}
 }
 
+// Ping - check connection to the redis. Can be used as health check
+func (m Model) Ping(ctx context.Context) error {
+	if m.storage == nil {
+		return errors.New("cache is nil")
+	}
+	return m.storage.Ping(ctx).Err()
+}
+
+// HealthCheck - critical health check of the redis with name "redis" for golang.Health.Register
+func (m Model) HealthCheck() golang.HealthCheck {
+	return golang.HealthCheck{Name: "redis", Check: m.Ping, Critical: true, CacheTTL: time.Second}
+}
+
 func (m Model) Set(ctx context.Context, key string, resp *Responce) error {
 	if resp == nil {
 		return errors.New("bad response for cache")
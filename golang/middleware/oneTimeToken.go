@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/blabu/egeonLib/golang"
+	"github.com/go-redis/redis/v8"
+)
+
+const oneTimeTokenPrefix = "one_time_token:"
+
+// incrWindow - INCR и установка окна счетчику без срока жизни одной операцией
+// (аналог EXPIRE NX, не теряет ttl если процесс упал между INCR и EXPIRE)
+var incrWindow = redis.NewScript(`
+local cnt = redis.call('INCR', KEYS[1])
+if redis.call('PTTL', KEYS[1]) == -1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return cnt`)
+
+// TokenStore - хранилище одноразовых токенов в redis
+type TokenStore struct {
+	cache Model
+}
+
+// NewTokenStore - create redis one time token store
+func NewTokenStore(cache Model) *TokenStore {
+	return &TokenStore{cache: cache}
+}
+
+func (s *TokenStore) Put(ctx context.Context, hash string, t golang.OneTimeToken) error {
+	if s.cache.storage == nil {
+		return errors.New("cache is nil")
+	}
+	ttl := time.Until(t.ExpireDate)
+	if ttl <= 0 {
+		return golang.Errors["badToken"]
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return s.cache.storage.Set(ctx, oneTimeTokenPrefix+hash, data, ttl).Err()
+}
+
+func (s *TokenStore) Take(ctx context.Context, hash string) (golang.OneTimeToken, error) {
+	var t golang.OneTimeToken
+	if s.cache.storage == nil {
+		return t, errors.New("cache is nil")
+	}
+	pipe := s.cache.storage.TxPipeline()
+	get := pipe.Get(ctx, oneTimeTokenPrefix+hash)
+	pipe.Del(ctx, oneTimeTokenPrefix+hash)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return t, err
+	}
+	data, err := get.Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return t, golang.Errors["badToken"]
+		}
+		return t, err
+	}
+	err = json.Unmarshal(data, &t)
+	return t, err
+}
+
+func (s *TokenStore) Hit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	if s.cache.storage == nil {
+		return 0, errors.New("cache is nil")
+	}
+	return incrWindow.Run(ctx, s.cache.storage, []string{key}, window.Milliseconds()).Int64()
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenStoreHitWindow(t *testing.T) {
+	m := testModel(t)
+	ctx := context.Background()
+	store := NewTokenStore(m)
+	// счетчик без ttl, оставшийся после сбоя между INCR и EXPIRE
+	m.storage.Set(ctx, "hits", 5, 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.Hit(ctx, "hits", time.Minute); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if cnt, _ := m.storage.Get(ctx, "hits").Int64(); cnt != 15 {
+		t.Fatalf("counter %d", cnt)
+	}
+	if ttl := m.storage.PTTL(ctx, "hits").Val(); ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("window ttl %v", ttl)
+	}
+	m.storage.PExpire(ctx, "hits", 10*time.Second)
+	if cnt, err := store.Hit(ctx, "hits", time.Minute); err != nil || cnt != 16 {
+		t.Fatalf("hit %d, %v", cnt, err)
+	}
+	if ttl := m.storage.PTTL(ctx, "hits").Val(); ttl > 10*time.Second {
+		t.Fatalf("window is extended: %v", ttl)
+	}
+}
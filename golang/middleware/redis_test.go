@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// testModel - redis from EGEON_TEST_REDIS (host:port). Test is skipped without it
+func testModel(t *testing.T) Model {
+	addr := os.Getenv("EGEON_TEST_REDIS")
+	if len(addr) == 0 {
+		t.Skip("EGEON_TEST_REDIS is not set")
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr, DB: 15})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Skip("redis is not available: ", err)
+	}
+	client.FlushDB(context.Background())
+	t.Cleanup(func() { client.Close() })
+	return Model{expireTime: time.Minute, storage: client}
+}
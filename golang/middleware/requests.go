@@ -35,10 +35,25 @@ func hash(url string) string {
 // BuildRequestMiddleware - create middleware that cached requests by user
 // requestPerUser - is a template key that is Sprintf template with to parameters %s and %s
 // requestPerUser key forms with userID and md5 hash sum for request URI
+// Deprecated: use BuildRequestLoggerMiddleware with leveled Logger
 func BuildRequestMiddleware(cache Model, log io.StringWriter, requestPerUser string) gin.HandlerFunc {
+	var l golang.Logger
+	if log != nil {
+		l = golang.NewStringWriterLogger(log, golang.DebugLevel)
+	}
+	return BuildRequestLoggerMiddleware(cache, l, requestPerUser)
+}
+
+// BuildRequestLoggerMiddleware - the same as BuildRequestMiddleware with leveled logger
+// log - can be nil, than logger of the library will be used
+func BuildRequestLoggerMiddleware(cache Model, log golang.Logger, requestPerUser string) gin.HandlerFunc {
+	if log == nil {
+		log = golang.GetLogger()
+	}
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
 		if cache.storage == nil {
-			log.WriteString("Cache is nil")
+			log.Warn(ctx, "cache is nil")
 			c.Next()
 			return
 		}
@@ -46,13 +61,13 @@ func BuildRequestMiddleware(cache Model, log io.StringWriter, requestPerUser str
 			c.Request.Method == http.MethodTrace ||
 			c.Request.Method == http.MethodHead ||
 			c.Request.Method == http.MethodConnect {
-			log.WriteString("Methods not cached")
+			log.Debug(ctx, "method not cached", "method", c.Request.Method)
 			c.Next()
 			return
 		}
 		user, ok := c.Request.Context().Value(golang.UserKey).(golang.User)
 		if !ok {
-			log.WriteString("Undefined user, work without cache")
+			log.Debug(ctx, "undefined user, work without cache")
 			c.Next()
 			return
 		}
@@ -63,31 +78,34 @@ func BuildRequestMiddleware(cache Model, log io.StringWriter, requestPerUser str
 			// clear cache for current user if we got some mutation request and it was success
 			c.Next()
 			if c.Writer.Status() < http.StatusBadRequest {
-				cache.Delete(c.Request.Context(), fmt.Sprintf(requestPerUser, strconv.FormatUint(uint64(user.ID), 10), "*"))
+				if err := cache.Delete(ctx, fmt.Sprintf(requestPerUser, strconv.FormatUint(uint64(user.ID), 10), "*")); err != nil {
+					log.Warn(ctx, "cache invalidation failed", "error", err)
+				}
 			}
 			return
 		}
 		key := fmt.Sprintf(requestPerUser, strconv.FormatUint(uint64(user.ID), 10), hash(c.Request.RequestURI))
-		if resp, err := cache.Get(c.Request.Context(), key); err == nil {
+		resp, err := cache.Get(ctx, key)
+		golang.ObserveCache("request", err == nil)
+		if err == nil {
 			for k, v := range resp.Header {
 				for i := range v {
-					log.WriteString(fmt.Sprintf("Add header %s: %s", k, v[i]))
 					c.Writer.Header().Add(k, v[i])
 				}
 			}
-			log.WriteString(fmt.Sprintf("Result found in cache. Status: %d", resp.Status))
+			log.Debug(ctx, "result found in cache", "key", key, "status", resp.Status, "headers", len(resp.Header))
 			c.Writer.WriteHeader(resp.Status)
 			c.Writer.Write(resp.Body)
 			c.Abort() //stop request execution
 			return
 		}
-		log.WriteString("Undefined result in cache")
+		log.Debug(ctx, "undefined result in cache", "key", key)
 		rw := writerWrap{ResponseWriter: c.Writer}
 		c.Writer = &rw
 		c.Next()
 		if rw.Status() < http.StatusBadRequest {
-			log.WriteString("Result saved to cache")
-			cache.Set(c.Request.Context(), key, &Responce{Body: rw.body.Bytes(), Status: rw.Status(), Header: map[string][]string(rw.Header())})
+			log.Debug(ctx, "result saved to cache", "key", key)
+			cache.Set(ctx, key, &Responce{Body: rw.body.Bytes(), Status: rw.Status(), Header: map[string][]string(rw.Header())})
 		}
 	}
 }
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/blabu/egeonLib/golang"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	sessionPrefix      = "session:"
+	userSessionsPrefix = "user_sessions:"
+)
+
+// extendTTL - продлевает время жизни ключа KEYS[1] до ARGV[1] миллисекунд, если оно меньше (или не задано)
+var extendTTL = redis.NewScript(`
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl == -1 or (ttl >= 0 and ttl < tonumber(ARGV[1])) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return ttl`)
+
+// SessionStore - хранилище сессий пользователей в redis.
+// Сессия хранится по ключу session:<key> до ExpireDate, ключи сессий пользователя - в множестве user_sessions:<userID>,
+// которое живет не меньше самой долгой сессии пользователя
+type SessionStore struct {
+	cache Model
+}
+
+// NewSessionStore - create redis session store
+func NewSessionStore(cache Model) *SessionStore {
+	return &SessionStore{cache: cache}
+}
+
+func userSessionsKey(userID uint32) string {
+	return userSessionsPrefix + strconv.FormatUint(uint64(userID), 10)
+}
+
+func (s *SessionStore) Save(ctx context.Context, session golang.Session) error {
+	if s.cache.storage == nil {
+		return errors.New("cache is nil")
+	}
+	ttl := time.Until(session.ExpireDate)
+	if ttl <= 0 {
+		return golang.Errors["badSession"]
+	}
+	data, err := session.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	setKey := userSessionsKey(session.UserID)
+	pipe := s.cache.storage.TxPipeline()
+	pipe.Set(ctx, sessionPrefix+string(session.Key), data, ttl)
+	pipe.SAdd(ctx, setKey, string(session.Key))
+	extendTTL.Eval(ctx, pipe, []string{setKey}, ttl.Milliseconds()) // EVALSHA не подходит: NOSCRIPT станет известен только в Exec
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Refresh - session is written with SET XX, so session revoked after reading is not restored
+func (s *SessionStore) Refresh(ctx context.Context, key golang.SessionKey, ttl time.Duration) (golang.Session, error) {
+	if s.cache.storage == nil {
+		return golang.Session{}, errors.New("cache is nil")
+	}
+	session, err := s.get(ctx, string(key))
+	if err != nil {
+		return session, err
+	}
+	session.LastActivity = time.Now()
+	session.ExpireDate = session.LastActivity.Add(ttl)
+	data, err := session.MarshalJSON()
+	if err != nil {
+		return session, err
+	}
+	ok, err := s.cache.storage.SetXX(ctx, sessionPrefix+string(key), data, ttl).Result()
+	if err != nil {
+		return session, err
+	}
+	if !ok {
+		return golang.Session{}, golang.Errors["badSession"]
+	}
+	err = extendTTL.Run(ctx, s.cache.storage, []string{userSessionsKey(session.UserID)}, ttl.Milliseconds()).Err()
+	return session, err
+}
+
+func (s *SessionStore) get(ctx context.Context, key string) (golang.Session, error) {
+	var session golang.Session
+	data, err := s.cache.storage.Get(ctx, sessionPrefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return session, golang.Errors["badSession"]
+		}
+		return session, err
+	}
+	if err = session.UnmarshalJSON(data); err != nil {
+		return session, err
+	}
+	if !session.ExpireDate.After(time.Now()) {
+		return session, golang.Errors["badSession"]
+	}
+	return session, nil
+}
+
+func (s *SessionStore) Get(ctx context.Context, key golang.SessionKey) (golang.Session, error) {
+	if s.cache.storage == nil {
+		return golang.Session{}, errors.New("cache is nil")
+	}
+	return s.get(ctx, string(key))
+}
+
+func (s *SessionStore) Revoke(ctx context.Context, key golang.SessionKey) error {
+	if s.cache.storage == nil {
+		return errors.New("cache is nil")
+	}
+	session, err := s.get(ctx, string(key))
+	if err != nil {
+		if errors.Is(err, golang.Errors["badSession"]) {
+			return s.cache.storage.Del(ctx, sessionPrefix+string(key)).Err()
+		}
+		return err
+	}
+	pipe := s.cache.storage.TxPipeline()
+	pipe.Del(ctx, sessionPrefix+string(key))
+	pipe.SRem(ctx, userSessionsKey(session.UserID), string(key))
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (s *SessionStore) RevokeAll(ctx context.Context, userID uint32) error {
+	if s.cache.storage == nil {
+		return errors.New("cache is nil")
+	}
+	setKey := userSessionsKey(userID)
+	keys, err := s.cache.storage.SMembers(ctx, setKey).Result()
+	if err != nil {
+		return err
+	}
+	pipe := s.cache.storage.TxPipeline()
+	for _, k := range keys {
+		pipe.Del(ctx, sessionPrefix+k)
+	}
+	pipe.Del(ctx, setKey)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// List - active sessions of the user. Keys of the expired sessions are removed from the user set
+func (s *SessionStore) List(ctx context.Context, userID uint32) ([]golang.Session, error) {
+	if s.cache.storage == nil {
+		return nil, errors.New("cache is nil")
+	}
+	setKey := userSessionsKey(userID)
+	keys, err := s.cache.storage.SMembers(ctx, setKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]golang.Session, 0, len(keys))
+	expired := make([]interface{}, 0)
+	for _, k := range keys {
+		session, err := s.get(ctx, k)
+		if err != nil {
+			if !errors.Is(err, golang.Errors["badSession"]) {
+				return nil, err
+			}
+			expired = append(expired, k)
+			continue
+		}
+		res = append(res, session)
+	}
+	if len(expired) != 0 {
+		s.cache.storage.SRem(ctx, setKey, expired...)
+	}
+	return res, nil
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blabu/egeonLib/golang"
+)
+
+func TestSessionStoreRefreshAfterRevoke(t *testing.T) {
+	m := testModel(t)
+	ctx := context.Background()
+	store := NewSessionStore(m)
+	user := golang.User{ID: 1}
+	s, err := golang.CreateSession(ctx, store, &user, nil, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl := m.storage.PTTL(ctx, userSessionsKey(1)).Val(); ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("user sessions ttl %v", ttl)
+	}
+	if _, err = store.Refresh(ctx, s.Key, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := m.storage.PTTL(ctx, userSessionsKey(1)).Val(); ttl <= time.Minute {
+		t.Fatalf("user sessions ttl is not extended: %v", ttl)
+	}
+	if err = store.RevokeAll(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.Refresh(ctx, s.Key, time.Hour); !errors.Is(err, golang.Errors["badSession"]) {
+		t.Fatalf("revoked session refreshed: %v", err)
+	}
+	if m.storage.Exists(ctx, sessionPrefix+string(s.Key)).Val() != 0 {
+		t.Fatal("revoked session restored")
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/blabu/egeonLib/golang"
+	"github.com/go-redis/redis/v8"
+)
+
+// UserLogStream - sink of the access log that add records to the redis stream
+type UserLogStream struct {
+	cache  Model
+	stream string
+	maxLen int64
+}
+
+// NewUserLogStream - create access log sink to the redis stream with name stream
+// maxLen - approximate max length of the stream (0 - unlimited)
+func NewUserLogStream(cache Model, stream string, maxLen int64) *UserLogStream {
+	return &UserLogStream{cache: cache, stream: stream, maxLen: maxLen}
+}
+
+func (s *UserLogStream) WriteLogs(ctx context.Context, logs []golang.UserLog) error {
+	if s.cache.storage == nil {
+		return errors.New("cache is nil")
+	}
+	pipe := s.cache.storage.Pipeline()
+	for i := range logs {
+		data, err := logs[i].MarshalJSON()
+		if err != nil {
+			return err
+		}
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream:       s.stream,
+			MaxLenApprox: s.maxLen,
+			Values:       map[string]interface{}{"log": data},
+		})
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
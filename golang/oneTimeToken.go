@@ -0,0 +1,204 @@
+package golang
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenPurpose - назначение одноразового токена. Токен одного назначения не может быть использован для другого
+type TokenPurpose string
+
+const (
+	PurposeEmailConfirm    TokenPurpose = "email_confirm"
+	PurposePasswordRestore TokenPurpose = "password_restore"
+)
+
+// OneTimeToken - одноразовый токен, привязанный к пользователю, назначению и времени жизни
+type OneTimeToken struct {
+	UserID     uint32       `json:"userID"`
+	Email      string       `json:"email"`
+	Purpose    TokenPurpose `json:"purpose"`
+	ExpireDate time.Time    `json:"expired"`
+}
+
+// OneTimeTokenStore - хранилище одноразовых токенов. Ключ - хеш токена, сам токен не хранится
+type OneTimeTokenStore interface {
+	// Put - save token data until t.ExpireDate
+	Put(ctx context.Context, hash string, t OneTimeToken) error
+	// Take - atomically get and delete token. Return Errors["badToken"] if it is absent
+	Take(ctx context.Context, hash string) (OneTimeToken, error)
+	// Hit - increment counter of the key in the window and return its value
+	Hit(ctx context.Context, key string, window time.Duration) (int64, error)
+}
+
+// TokenHash - key of the token in the store
+func TokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// OneTimeTokens - выдача и проверка токенов подтверждения почты и восстановления пароля
+type OneTimeTokens struct {
+	Store       OneTimeTokenStore
+	TTL         time.Duration // время жизни токена
+	MaxPerEmail int64         // максимальное количество токенов на один email за Window (0 - без ограничения)
+	Window      time.Duration
+}
+
+// NewOneTimeTokens - tokens with life time ttl, not more than maxPerEmail tokens per hour for one email
+func NewOneTimeTokens(store OneTimeTokenStore, ttl time.Duration, maxPerEmail int64) *OneTimeTokens {
+	return &OneTimeTokens{Store: store, TTL: ttl, MaxPerEmail: maxPerEmail, Window: time.Hour}
+}
+
+// Issue - create token of purpose for the user. Token must be sent to the user, it is not saved anywhere
+func (o *OneTimeTokens) Issue(ctx context.Context, user *User, purpose TokenPurpose) (string, error) {
+	if len(user.Email) == 0 {
+		return "", EgeonError{Code: IncorrectRequestParam, Description: Errors["badEmail"].Error()}
+	}
+	if o.MaxPerEmail > 0 {
+		cnt, err := o.Store.Hit(ctx, "token_rate:"+strings.ToLower(user.Email), o.Window)
+		if err != nil {
+			return "", EgeonError{Code: InternalError, Description: err.Error()}
+		}
+		if cnt > o.MaxPerEmail {
+			return "", EgeonError{Code: Wait, Description: Errors["tooManyRequests"].Error()}
+		}
+	}
+	token, err := NewToken()
+	if err != nil {
+		return "", EgeonError{Code: InternalError, Description: err.Error()}
+	}
+	t := OneTimeToken{
+		UserID:     user.ID,
+		Email:      user.Email,
+		Purpose:    purpose,
+		ExpireDate: time.Now().Add(o.TTL),
+	}
+	if err = o.Store.Put(ctx, TokenHash(token), t); err != nil {
+		return "", EgeonError{Code: InternalError, Description: err.Error()}
+	}
+	return token, nil
+}
+
+// Verify - check and consume token. Token can be used only once, even if purpose is wrong
+func (o *OneTimeTokens) Verify(ctx context.Context, token string, purpose TokenPurpose) (OneTimeToken, error) {
+	t, err := o.Store.Take(ctx, TokenHash(token))
+	if err != nil {
+		if err == Errors["badToken"] {
+			return t, EgeonError{Code: NotAuthError, Description: err.Error()}
+		}
+		return t, EgeonError{Code: InternalError, Description: err.Error()}
+	}
+	if t.Purpose != purpose || !t.ExpireDate.After(time.Now()) {
+		return OneTimeToken{}, EgeonError{Code: NotAuthError, Description: Errors["badToken"].Error()}
+	}
+	return t, nil
+}
+
+// ConfirmEmail - consume email confirmation token and mark email of the user as confirmed.
+// User must be the owner of the token and email must not be changed after token was issued
+func (o *OneTimeTokens) ConfirmEmail(ctx context.Context, user *User, token string) error {
+	t, err := o.Verify(ctx, token, PurposeEmailConfirm)
+	if err != nil {
+		return err
+	}
+	if t.UserID != user.ID || !strings.EqualFold(t.Email, user.Email) {
+		return EgeonError{Code: NotAuthError, Description: Errors["badToken"].Error()}
+	}
+	user.IsEmailConfirm = true
+	return nil
+}
+
+// RestorePassword - consume password restore token, set new password and unlock the user
+func (o *OneTimeTokens) RestorePassword(ctx context.Context, p *Passwords, user *User, token, password string) error {
+	t, err := o.Verify(ctx, token, PurposePasswordRestore)
+	if err != nil {
+		return err
+	}
+	if t.UserID != user.ID {
+		return EgeonError{Code: NotAuthError, Description: Errors["badToken"].Error()}
+	}
+	if err = p.SetPassword(user, password); err != nil {
+		return EgeonError{Code: InternalError, Description: err.Error()}
+	}
+	p.Unlock(user)
+	user.RestorePassword = false
+	return nil
+}
+
+// Таблицы LocalCache для одноразовых токенов
+const (
+	oneTimeTokensTable uint32 = iota
+	tokenRateTable
+)
+
+type rateCounter struct {
+	count int64
+	till  time.Time
+}
+
+// LocalTokenStore - хранилище одноразовых токенов в памяти процесса на основе LocalCache.
+// Истекшие токены и счетчики удаляются при записи новых, не чаще localSweepInterval
+type LocalTokenStore struct {
+	cache     *LocalCache
+	mt        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewLocalTokenStore - create in memory token store
+func NewLocalTokenStore() *LocalTokenStore {
+	return &LocalTokenStore{cache: GetNewNamedCache("oneTimeTokens", 0, oneTimeTokensTable, tokenRateTable)}
+}
+
+// sweep - remove expired tokens and rate counters. Called with ls.mt locked
+func (ls *LocalTokenStore) sweep(now time.Time) {
+	if now.Sub(ls.lastSweep) < localSweepInterval {
+		return
+	}
+	ls.lastSweep = now
+	ls.cache.Sweep(oneTimeTokensTable, func(key, value interface{}) bool {
+		t, ok := value.(OneTimeToken)
+		return !ok || !t.ExpireDate.After(now)
+	})
+	ls.cache.Sweep(tokenRateTable, func(key, value interface{}) bool {
+		c, ok := value.(rateCounter)
+		return !ok || !c.till.After(now)
+	})
+}
+
+func (ls *LocalTokenStore) Put(ctx context.Context, hash string, t OneTimeToken) error {
+	ls.mt.Lock()
+	defer ls.mt.Unlock()
+	ls.sweep(time.Now())
+	ls.cache.StoreItem(oneTimeTokensTable, hash, t)
+	return nil
+}
+
+func (ls *LocalTokenStore) Take(ctx context.Context, hash string) (OneTimeToken, error) {
+	ls.mt.Lock()
+	defer ls.mt.Unlock()
+	t, ok := ls.cache.GetItem(oneTimeTokensTable, hash).(OneTimeToken)
+	if !ok {
+		return t, Errors["badToken"]
+	}
+	ls.cache.DeleteItem(oneTimeTokensTable, hash)
+	return t, nil
+}
+
+func (ls *LocalTokenStore) Hit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	ls.mt.Lock()
+	defer ls.mt.Unlock()
+	now := time.Now()
+	ls.sweep(now)
+	c, ok := ls.cache.GetItem(tokenRateTable, key).(rateCounter)
+	if !ok || !c.till.After(now) {
+		c = rateCounter{till: now.Add(window)}
+	}
+	c.count++
+	ls.cache.StoreItem(tokenRateTable, key, c)
+	return c.count, nil
+}
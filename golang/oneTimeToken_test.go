@@ -0,0 +1,30 @@
+package golang
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLocalTokenStoreSweep(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalTokenStore()
+	store.Put(ctx, "expired", OneTimeToken{UserID: 1, ExpireDate: time.Now().Add(-time.Second)})
+	store.Put(ctx, "active", OneTimeToken{UserID: 1, ExpireDate: time.Now().Add(time.Minute)})
+	store.Hit(ctx, "short", time.Nanosecond)
+	store.Hit(ctx, "long", time.Minute)
+	time.Sleep(time.Millisecond)
+
+	store.mt.Lock()
+	store.lastSweep = time.Time{}
+	store.mt.Unlock()
+	if n, _ := store.Hit(ctx, "long", time.Minute); n != 2 {
+		t.Errorf("rate counter %d", n)
+	}
+	if store.cache.GetItem(oneTimeTokensTable, "expired") != nil || store.cache.GetItem(tokenRateTable, "short") != nil {
+		t.Error("expired records are kept")
+	}
+	if _, err := store.Take(ctx, "active"); err != nil {
+		t.Errorf("active token: %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package golang
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Параметры строки запроса для постраничной навигации
+const (
+	LimitQueryKey  = "limit"
+	OffsetQueryKey = "offset"
+	CursorQueryKey = "cursor"
+	SortQueryKey   = "sort"   // sort=name,-addedDate (минус - по убыванию)
+	FilterQueryKey = "filter" // может повторяться, выражения объединяются через and
+)
+
+// SortField - поле сортировки (имя поля в json)
+type SortField struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc,omitempty"`
+}
+
+// PageRequest - параметры запроса страницы списка
+type PageRequest struct {
+	Limit  int
+	Offset int
+	// Cursor - значения ключевых полей последней записи предыдущей страницы (keyset навигация).
+	// Если задан, Offset не используется
+	Cursor  map[string]interface{}
+	Sort    []SortField
+	Filters []string // выражения фильтра, разбираются ParseFilter
+}
+
+// PageOptions - ограничения параметров запроса страницы для конкретного списка
+type PageOptions struct {
+	DefaultLimit int
+	MaxLimit     int
+	SortFields   []string    // поля, по которым разрешена сортировка
+	DefaultSort  []SortField // сортировка, если она не задана в запросе
+	Cursors      *CursorCodec
+}
+
+// DefaultPageOptions - 20 records per page, not more than 100, cursors are signed by EGEON_SECRET_KEY.
+// Without EGEON_SECRET_KEY cursors are not supported (Cursors is nil)
+func DefaultPageOptions(sortFields ...string) PageOptions {
+	cursors, _ := NewCursorCodec(nil)
+	return PageOptions{DefaultLimit: 20, MaxLimit: 100, SortFields: sortFields, Cursors: cursors}
+}
+
+// SortString - sort in query string format (name,-addedDate)
+func SortString(sort []SortField) string {
+	parts := make([]string, len(sort))
+	for i, s := range sort {
+		if s.Desc {
+			parts[i] = "-" + s.Field
+		} else {
+			parts[i] = s.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func allowedField(field string, allowed []string) bool {
+	for _, f := range allowed {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePageRequest - parse and validate page parameters from query string.
+// Return EgeonError with code ValidateError and all incorrect parameters
+func ParsePageRequest(query url.Values, opt PageOptions) (PageRequest, error) {
+	req := PageRequest{Limit: opt.DefaultLimit, Sort: opt.DefaultSort, Filters: query[FilterQueryKey]}
+	var fields []FieldError
+	if s := query.Get(LimitQueryKey); len(s) != 0 {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit <= 0 || opt.MaxLimit > 0 && limit > opt.MaxLimit {
+			msg := "значення має бути додатнім числом"
+			if opt.MaxLimit > 0 {
+				msg = "значення має бути від 1 до " + strconv.Itoa(opt.MaxLimit)
+			}
+			fields = append(fields, FieldError{Field: LimitQueryKey, Message: msg})
+		} else {
+			req.Limit = limit
+		}
+	}
+	if s := query.Get(OffsetQueryKey); len(s) != 0 {
+		offset, err := strconv.Atoi(s)
+		if err != nil || offset < 0 {
+			fields = append(fields, FieldError{Field: OffsetQueryKey, Message: "значення має бути невід'ємним числом"})
+		} else {
+			req.Offset = offset
+		}
+	}
+	if s := query.Get(SortQueryKey); len(s) != 0 {
+		req.Sort = nil
+		for _, f := range strings.Split(s, ",") {
+			f = strings.TrimSpace(f)
+			sf := SortField{Field: strings.TrimPrefix(strings.TrimPrefix(f, "-"), "+"), Desc: strings.HasPrefix(f, "-")}
+			if !allowedField(sf.Field, opt.SortFields) {
+				fields = append(fields, FieldError{Field: SortQueryKey, Message: "сортування за полем " + sf.Field + " не підтримується"})
+				continue
+			}
+			req.Sort = append(req.Sort, sf)
+		}
+	}
+	if s := query.Get(CursorQueryKey); len(s) != 0 {
+		if opt.Cursors == nil {
+			fields = append(fields, FieldError{Field: CursorQueryKey, Message: Errors["notImplement"].Error()})
+		} else if cursor, err := opt.Cursors.Decode(s, req.Sort, req.Filters); err != nil {
+			fields = append(fields, FieldError{Field: CursorQueryKey, Message: err.Error()})
+		} else {
+			req.Cursor = cursor
+			req.Offset = 0
+		}
+	}
+	if len(fields) != 0 {
+		return req, NewValidateError(fields...)
+	}
+	return req, nil
+}
+
+// PageRequestFromGin - parse page parameters of the gin request
+func PageRequestFromGin(c *gin.Context, opt PageOptions) (PageRequest, error) {
+	return ParsePageRequest(c.Request.URL.Query(), opt)
+}
+
+// PageRequestFromHTTP - parse page parameters of the http request
+func PageRequestFromHTTP(r *http.Request, opt PageOptions) (PageRequest, error) {
+	return ParsePageRequest(r.URL.Query(), opt)
+}
+
+// NextCursor - cursor after the record with values of the key fields, bound to the sort and filters of the request
+func (p PageRequest) NextCursor(cc *CursorCodec, values map[string]interface{}) (string, error) {
+	return cc.Encode(values, p.Sort, p.Filters)
+}
+
+// PageInfo - page info of the response. next - cursor of the next page (empty for the last page)
+func (p PageRequest) PageInfo(total int64, next string) PageInfo {
+	return PageInfo{Total: total, Limit: p.Limit, Offset: p.Offset, NextCursor: next}
+}
+
+var (
+	errBadCursor = errors.New("курсор пошкоджено або він створений для іншого сортування чи фільтра")
+	// ErrEmptyCursorSecret - курсоры нельзя подписать пустым секретом
+	ErrEmptyCursorSecret = errors.New("cursor secret is empty")
+)
+
+// CursorCodec - кодирует позицию keyset навигации в непрозрачную строку, подписанную HMAC-SHA256.
+// Клиент не может изменить позицию или использовать курсор с другой сортировкой или фильтром
+type CursorCodec struct {
+	key []byte // HMAC(secret, "cursor"), секрет не используется для курсоров напрямую
+}
+
+// NewCursorCodec - codec with secret. Nil secret - value of EGEON_SECRET_KEY environment variable.
+// Return ErrEmptyCursorSecret if secret is empty
+func NewCursorCodec(secret []byte) (*CursorCodec, error) {
+	if secret == nil {
+		secret = []byte(os.Getenv(EgeonSecretKeyEnviron))
+	}
+	if len(secret) == 0 {
+		return nil, ErrEmptyCursorSecret
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("cursor"))
+	return &CursorCodec{key: mac.Sum(nil)}, nil
+}
+
+type cursorData struct {
+	Sort   string                 `json:"s,omitempty"`
+	Filter string                 `json:"f,omitempty"` // хеш выражений фильтра
+	Values map[string]interface{} `json:"v"`
+}
+
+func (cc *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, cc.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// filterDigest - digest of the filter expressions, order of the expressions is not important (they are joined by and)
+func filterDigest(filters []string) string {
+	if len(filters) == 0 {
+		return ""
+	}
+	sorted := append([]string(nil), filters...)
+	sort.Strings(sorted)
+	h := sha256.New()
+	for _, f := range sorted {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:16])
+}
+
+// Encode - cursor of the position after the record with values of the key fields for the sort and filters
+func (cc *CursorCodec) Encode(values map[string]interface{}, sort []SortField, filters []string) (string, error) {
+	if cc == nil || len(cc.key) == 0 {
+		return "", ErrEmptyCursorSecret
+	}
+	payload, err := json.Marshal(cursorData{Sort: SortString(sort), Filter: filterDigest(filters), Values: values})
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(cc.sign(payload)), nil
+}
+
+// Decode - check signature, sort and filters of the cursor and return values of the key fields
+func (cc *CursorCodec) Decode(cursor string, sort []SortField, filters []string) (map[string]interface{}, error) {
+	if cc == nil || len(cc.key) == 0 {
+		return nil, ErrEmptyCursorSecret
+	}
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 2 {
+		return nil, errBadCursor
+	}
+	enc := base64.RawURLEncoding
+	payload, err := enc.DecodeString(parts[0])
+	if err != nil {
+		return nil, errBadCursor
+	}
+	sig, err := enc.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(sig, cc.sign(payload)) {
+		return nil, errBadCursor
+	}
+	var data cursorData
+	dec := json.NewDecoder(strings.NewReader(string(payload)))
+	dec.UseNumber()
+	if err = dec.Decode(&data); err != nil || data.Sort != SortString(sort) || data.Filter != filterDigest(filters) {
+		return nil, errBadCursor
+	}
+	return data.Values, nil
+}
+
+// Page - конверт страницы для типов, у которых нет отдельного типа страницы (GroupPage, UserPage и т.д.).
+// Пустая страница - это пустой список Items, а не ошибка
+type Page struct {
+	Items      interface{} `json:"items"`
+	Total      int64       `json:"total"`
+	Limit      int         `json:"limit"`
+	Offset     int         `json:"offset,omitempty"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+}
+
+// NewPage - page of items (slice) with info
+func NewPage(items interface{}, info PageInfo) Page {
+	return Page{Items: items, Total: info.Total, Limit: info.Limit, Offset: info.Offset, NextCursor: info.NextCursor}
+}
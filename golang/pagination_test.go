@@ -0,0 +1,98 @@
+package golang
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCursorCodecSecret(t *testing.T) {
+	if _, err := NewCursorCodec([]byte{}); err != ErrEmptyCursorSecret {
+		t.Fatalf("empty secret: %v", err)
+	}
+	old, had := os.LookupEnv(EgeonSecretKeyEnviron)
+	os.Unsetenv(EgeonSecretKeyEnviron)
+	defer func() {
+		if had {
+			os.Setenv(EgeonSecretKeyEnviron, old)
+		}
+	}()
+	if _, err := NewCursorCodec(nil); err != ErrEmptyCursorSecret {
+		t.Fatalf("nil secret without environment: %v", err)
+	}
+	if DefaultPageOptions("id").Cursors != nil {
+		t.Fatal("cursors without secret")
+	}
+	var zero CursorCodec
+	if _, err := zero.Encode(map[string]interface{}{"id": 1}, nil, nil); err != ErrEmptyCursorSecret {
+		t.Fatalf("zero codec: %v", err)
+	}
+	_, err := ParsePageRequest(url.Values{CursorQueryKey: {"x.y"}}, DefaultPageOptions("id"))
+	var e EgeonError
+	if !errors.As(err, &e) || e.FieldList()[0].Message != Errors["notImplement"].Error() {
+		t.Fatalf("cursor without codec: %v", err)
+	}
+}
+
+func TestCursorCodecBinding(t *testing.T) {
+	cc, err := NewCursorCodec([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := NewCursorCodec([]byte("other"))
+	sortByName := []SortField{{Field: "name"}, {Field: "id", Desc: true}}
+	filters := []string{"status eq 1", "name ne 'x'"}
+	cursor, err := cc.Encode(map[string]interface{}{"name": "Київ", "id": 42}, sortByName, filters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := cc.Decode(cursor, sortByName, []string{filters[1], filters[0]})
+	if err != nil || values["name"] != "Київ" || values["id"].(interface{ String() string }).String() != "42" {
+		t.Fatalf("decoded %v, %v", values, err)
+	}
+	payload := strings.Split(cursor, ".")[0]
+	cases := []struct {
+		name    string
+		codec   *CursorCodec
+		cursor  string
+		sort    []SortField
+		filters []string
+	}{
+		{"other sort", cc, cursor, []SortField{{Field: "name"}}, filters},
+		{"other filter", cc, cursor, sortByName, []string{"status eq 2", "name ne 'x'"}},
+		{"without filter", cc, cursor, sortByName, nil},
+		{"other secret", other, cursor, sortByName, filters},
+		{"changed payload", cc, payload + "A." + strings.Split(cursor, ".")[1], sortByName, filters},
+		{"no signature", cc, payload, sortByName, filters},
+		{"empty", cc, "", sortByName, filters},
+	}
+	for _, c := range cases {
+		if _, err := c.codec.Decode(c.cursor, c.sort, c.filters); err != errBadCursor {
+			t.Errorf("%s: %v", c.name, err)
+		}
+	}
+}
+
+func TestParsePageRequestCursor(t *testing.T) {
+	cc, _ := NewCursorCodec([]byte("secret"))
+	opt := PageOptions{DefaultLimit: 20, MaxLimit: 100, SortFields: []string{"name", "id"}, Cursors: cc}
+	first, err := ParsePageRequest(url.Values{SortQueryKey: {"name,-id"}, FilterQueryKey: {"status eq 1"}}, opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, err := first.NextCursor(cc, map[string]interface{}{"name": "a", "id": 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := url.Values{SortQueryKey: {"name,-id"}, FilterQueryKey: {"status eq 1"}, CursorQueryKey: {next}, OffsetQueryKey: {"40"}}
+	req, err := ParsePageRequest(q, opt)
+	if err != nil || req.Offset != 0 || req.Cursor["name"] != "a" {
+		t.Fatalf("%+v, %v", req, err)
+	}
+	q.Set(FilterQueryKey, "status eq 2")
+	if _, err = ParsePageRequest(q, opt); err == nil {
+		t.Fatal("cursor accepted with other filter")
+	}
+}
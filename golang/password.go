@@ -0,0 +1,330 @@
+package golang
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+)
+
+var errBadPasswordHash = errors.New("unsupported or malformed password hash")
+
+var b64 = base64.RawStdEncoding
+
+// PasswordHasher - алгоритм хеширования паролей. Hash возвращает строку в формате PHC
+// ($<id>$<param>=<value>,...$<salt>$<hash>), в которой сохранены все параметры хеширования
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify - check password. Return errBadPasswordHash if encoded is not produced by this algorithm
+	Verify(password, encoded string) (bool, error)
+	// NeedsRehash - true if encoded was created by this algorithm with other parameters
+	NeedsRehash(encoded string) bool
+	// Match - true if encoded was created by this algorithm
+	Match(encoded string) bool
+}
+
+func randomSalt(n int) ([]byte, error) {
+	salt := make([]byte, n)
+	_, err := rand.Read(salt)
+	return salt, err
+}
+
+// Argon2idHasher - argon2id (рекомендуемый алгоритм)
+type Argon2idHasher struct {
+	Time    uint32
+	Memory  uint32 // KiB
+	Threads uint8
+	KeyLen  uint32
+	SaltLen int
+}
+
+// DefaultArgon2id - recommended parameters of argon2id
+var DefaultArgon2id = Argon2idHasher{Time: 3, Memory: 64 * 1024, Threads: 2, KeyLen: 32, SaltLen: 16}
+
+func (a Argon2idHasher) Match(encoded string) bool {
+	return strings.HasPrefix(encoded, "$argon2id$")
+}
+
+func (a Argon2idHasher) Hash(password string) (string, error) {
+	salt, err := randomSalt(a.SaltLen)
+	if err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, a.Time, a.Memory, a.Threads, a.KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, a.Memory, a.Time, a.Threads, b64.EncodeToString(salt), b64.EncodeToString(key)), nil
+}
+
+func (a Argon2idHasher) decode(encoded string) (params Argon2idHasher, salt, key []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, errBadPasswordHash
+	}
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errBadPasswordHash
+	}
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return params, nil, nil, errBadPasswordHash
+	}
+	if salt, err = b64.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, errBadPasswordHash
+	}
+	if key, err = b64.DecodeString(parts[5]); err != nil {
+		return params, nil, nil, errBadPasswordHash
+	}
+	// пустые соль, ключ или нулевые параметры: с пустым ключом любой пароль совпадает, argon2 паникует
+	if len(salt) == 0 || len(key) == 0 || params.Time < 1 || params.Threads < 1 {
+		return params, nil, nil, errBadPasswordHash
+	}
+	params.SaltLen = len(salt)
+	params.KeyLen = uint32(len(key))
+	return params, salt, key, nil
+}
+
+func (a Argon2idHasher) Verify(password, encoded string) (bool, error) {
+	p, salt, key, err := a.decode(encoded)
+	if err != nil {
+		return false, err
+	}
+	other := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+func (a Argon2idHasher) NeedsRehash(encoded string) bool {
+	p, _, _, err := a.decode(encoded)
+	return err != nil || p != a
+}
+
+// BcryptHasher - bcrypt. Хеш в формате модульного crypt ($2a$<cost>$...)
+type BcryptHasher struct {
+	Cost int
+}
+
+func (b BcryptHasher) Match(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+func (b BcryptHasher) Hash(password string) (string, error) {
+	data, err := bcrypt.GenerateFromPassword([]byte(password), b.Cost)
+	return string(data), err
+}
+
+func (b BcryptHasher) Verify(password, encoded string) (bool, error) {
+	if !b.Match(encoded) {
+		return false, errBadPasswordHash
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (b BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != b.Cost
+}
+
+// ScryptHasher - scrypt с параметрами N = 2^LogN, r, p
+type ScryptHasher struct {
+	LogN    uint8
+	R       int
+	P       int
+	KeyLen  int
+	SaltLen int
+}
+
+func (s ScryptHasher) Match(encoded string) bool {
+	return strings.HasPrefix(encoded, "$scrypt$")
+}
+
+func (s ScryptHasher) Hash(password string) (string, error) {
+	salt, err := randomSalt(s.SaltLen)
+	if err != nil {
+		return "", err
+	}
+	key, err := scrypt.Key([]byte(password), salt, 1<<s.LogN, s.R, s.P, s.KeyLen)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("$scrypt$ln=%d,r=%d,p=%d$%s$%s", s.LogN, s.R, s.P, b64.EncodeToString(salt), b64.EncodeToString(key)), nil
+}
+
+func (s ScryptHasher) decode(encoded string) (params ScryptHasher, salt, key []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[1] != "scrypt" {
+		return params, nil, nil, errBadPasswordHash
+	}
+	if _, err = fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &params.LogN, &params.R, &params.P); err != nil {
+		return params, nil, nil, errBadPasswordHash
+	}
+	if salt, err = b64.DecodeString(parts[3]); err != nil {
+		return params, nil, nil, errBadPasswordHash
+	}
+	if key, err = b64.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, errBadPasswordHash
+	}
+	if len(salt) == 0 || len(key) == 0 || params.LogN < 1 || params.R < 1 || params.P < 1 {
+		return params, nil, nil, errBadPasswordHash
+	}
+	params.SaltLen = len(salt)
+	params.KeyLen = len(key)
+	return params, salt, key, nil
+}
+
+func (s ScryptHasher) Verify(password, encoded string) (bool, error) {
+	p, salt, key, err := s.decode(encoded)
+	if err != nil {
+		return false, err
+	}
+	other, err := scrypt.Key([]byte(password), salt, 1<<p.LogN, p.R, p.P, p.KeyLen)
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+func (s ScryptHasher) NeedsRehash(encoded string) bool {
+	p, _, _, err := s.decode(encoded)
+	return err != nil || p != s
+}
+
+// VerifyLegacySHA256 - check password hashed by old services as sha256(password + salt)
+// hash can be encoded as base64 or hex
+func VerifyLegacySHA256(password, salt, hash string) bool {
+	sum := sha256.Sum256([]byte(password + salt))
+	if subtle.ConstantTimeCompare([]byte(base64.StdEncoding.EncodeToString(sum[:])), []byte(hash)) == 1 {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(strings.ToLower(hash))) == 1
+}
+
+// Passwords - установка и проверка паролей пользователя с блокировкой по AccessFailedCount
+type Passwords struct {
+	Current     PasswordHasher   // Этим алгоритмом хешируются новые пароли
+	Known       []PasswordHasher // Алгоритмы, которыми могут быть захешированы старые пароли
+	MaxFailed   int              // После MaxFailed неудачных попыток пользователь блокируется (0 - без блокировки)
+	LockoutTime time.Duration    // Блокировка снимается через LockoutTime после последней неудачной попытки (0 - только Unlock)
+	DelayBase   time.Duration    // Задержка после первой неудачной попытки, далее удваивается
+	DelayMax    time.Duration
+	AllowLegacy bool // Разрешить проверку старых хешей sha256(password + salt)
+}
+
+// NewPasswords - argon2id for new passwords, bcrypt and scrypt hashes are verified too
+func NewPasswords(maxFailed int) *Passwords {
+	return &Passwords{
+		Current:     DefaultArgon2id,
+		Known:       []PasswordHasher{BcryptHasher{Cost: bcrypt.DefaultCost}, ScryptHasher{LogN: 15, R: 8, P: 1, KeyLen: 32, SaltLen: 16}},
+		MaxFailed:   maxFailed,
+		LockoutTime: 15 * time.Minute,
+		DelayBase:   time.Second,
+		DelayMax:    time.Minute,
+		AllowLegacy: true,
+	}
+}
+
+// SetPassword - hash password and store it in the user. Salt is a part of PHC string, so User.Salt is cleared
+func (p *Passwords) SetPassword(u *User, password string) error {
+	encoded, err := p.Current.Hash(password)
+	if err != nil {
+		return err
+	}
+	u.PassHash = encoded
+	u.Salt = ""
+	return nil
+}
+
+func (p *Passwords) verify(u *User, password string) (ok, rehash bool, err error) {
+	if !strings.HasPrefix(u.PassHash, "$") {
+		if !p.AllowLegacy || len(u.PassHash) == 0 {
+			return false, false, errBadPasswordHash
+		}
+		ok = VerifyLegacySHA256(password, u.Salt, u.PassHash)
+		return ok, ok, nil
+	}
+	if p.Current.Match(u.PassHash) {
+		ok, err = p.Current.Verify(password, u.PassHash)
+		return ok, ok && p.Current.NeedsRehash(u.PassHash), err
+	}
+	for _, h := range p.Known {
+		if h.Match(u.PassHash) {
+			ok, err = h.Verify(password, u.PassHash)
+			return ok, ok, err
+		}
+	}
+	return false, false, errBadPasswordHash
+}
+
+// IsLocked - user has too many failed attempts and LockoutTime since the last of them is not passed
+func (p *Passwords) IsLocked(u User) bool {
+	if p.MaxFailed <= 0 || u.AccessFailedCount < p.MaxFailed {
+		return false
+	}
+	return p.LockoutTime <= 0 || time.Since(u.LastFailedLogin) < p.LockoutTime
+}
+
+// FailureDelay - delay before next attempt after AccessFailedCount failures
+func (p *Passwords) FailureDelay(u User) time.Duration {
+	if u.AccessFailedCount <= 0 || p.DelayBase <= 0 {
+		return 0
+	}
+	delay := p.DelayBase
+	for i := 1; i < u.AccessFailedCount && (p.DelayMax <= 0 || delay < p.DelayMax); i++ {
+		delay *= 2
+	}
+	if p.DelayMax > 0 && delay > p.DelayMax {
+		delay = p.DelayMax
+	}
+	return delay
+}
+
+// Unlock - reset failed attempts counter (after password restore or by administrator)
+func (p *Passwords) Unlock(u *User) {
+	u.AccessFailedCount = 0
+	u.LastFailedLogin = time.Time{}
+}
+
+// Login - check password of the user, update AccessFailedCount and LastFailedLogin (caller must save them in any case).
+// Expired lock is removed and counting starts again. Malformed stored hash is an internal error, not a failed attempt.
+// If hash was made by old algorithm or parameters, password is rehashed and rehashed is true,
+// in this case caller must save PassHash and Salt of the user too
+func (p *Passwords) Login(ctx context.Context, u *User, password string) (rehashed bool, err error) {
+	if p.IsLocked(*u) {
+		return false, EgeonError{Code: Permission, Description: Errors["permission"].Error()}
+	}
+	if p.MaxFailed > 0 && u.AccessFailedCount >= p.MaxFailed {
+		p.Unlock(u)
+	}
+	if len(u.PassHash) == 0 { // пароль не установлен
+		return false, EgeonError{Code: NotAuthError, Description: Errors["badKey"].Error()}
+	}
+	ok, rehash, err := p.verify(u, password)
+	if err != nil {
+		GetLogger().Error(ctx, "password hash verification failed", "user", u.ID, "error", err)
+		return false, EgeonError{Code: InternalError, Description: Errors["internal"].Error()}
+	}
+	if !ok {
+		u.AccessFailedCount++
+		u.LastFailedLogin = time.Now()
+		return false, EgeonError{Code: NotAuthError, Description: Errors["badKey"].Error()}
+	}
+	p.Unlock(u)
+	if rehash {
+		if err = p.SetPassword(u, password); err != nil {
+			GetLogger().Error(ctx, "password rehash failed", "user", u.ID, "error", err)
+			return false, EgeonError{Code: InternalError, Description: Errors["internal"].Error()}
+		}
+		return true, nil
+	}
+	return false, nil
+}
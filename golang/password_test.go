@@ -0,0 +1,110 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func testPasswords() *Passwords {
+	p := NewPasswords(3)
+	p.Current = Argon2idHasher{Time: 1, Memory: 1024, Threads: 1, KeyLen: 16, SaltLen: 8}
+	p.Known = []PasswordHasher{BcryptHasher{Cost: bcrypt.MinCost}, DefaultArgon2id, ScryptHasher{LogN: 4, R: 8, P: 1, KeyLen: 16, SaltLen: 8}}
+	p.LockoutTime = time.Minute
+	return p
+}
+
+func loginCode(err error) uint32 {
+	var e EgeonError
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return 0
+}
+
+func TestLoginLockout(t *testing.T) {
+	p := testPasswords()
+	var u User
+	if err := p.SetPassword(&u, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := p.Login(context.Background(), &u, "wrong"); loginCode(err) != NotAuthError {
+			t.Fatalf("attempt %d: %v", i, err)
+		}
+	}
+	if !p.IsLocked(u) {
+		t.Fatal("user is not locked")
+	}
+	if _, err := p.Login(context.Background(), &u, "secret"); loginCode(err) != Permission {
+		t.Fatalf("locked user logged in: %v", err)
+	}
+	u.LastFailedLogin = time.Now().Add(-2 * time.Minute)
+	if p.IsLocked(u) {
+		t.Fatal("lock is not expired")
+	}
+	if _, err := p.Login(context.Background(), &u, "wrong"); loginCode(err) != NotAuthError || u.AccessFailedCount != 1 {
+		t.Fatalf("counter after expired lock: %d, %v", u.AccessFailedCount, err)
+	}
+	if _, err := p.Login(context.Background(), &u, "secret"); err != nil || u.AccessFailedCount != 0 || !u.LastFailedLogin.IsZero() {
+		t.Fatalf("login: %v, %+v", err, u)
+	}
+
+	p.LockoutTime = 0
+	u.AccessFailedCount, u.LastFailedLogin = 3, time.Now().Add(-time.Hour)
+	if !p.IsLocked(u) {
+		t.Fatal("lock without lockout time expired")
+	}
+}
+
+func TestLoginMalformedHash(t *testing.T) {
+	p := testPasswords()
+	cases := []struct {
+		hash string
+		code uint32
+	}{
+		{"$argon2id$v=19$m=bad$salt$hash", InternalError},
+		{"$argon2id$v=19$m=65536,t=3,p=2$c2FsdHNhbHQ$", InternalError},
+		{"$argon2id$v=19$m=65536,t=3,p=2$$a2V5a2V5a2V5", InternalError},
+		{"$argon2id$v=19$m=65536,t=0,p=2$c2FsdHNhbHQ$a2V5a2V5a2V5", InternalError},
+		{"$argon2id$v=19$m=65536,t=3,p=0$c2FsdHNhbHQ$a2V5a2V5a2V5", InternalError},
+		{"$scrypt$ln=15,r=8,p=1$c2FsdHNhbHQ$", InternalError},
+		{"$scrypt$ln=15,r=8,p=1$$a2V5a2V5a2V5", InternalError},
+		{"$scrypt$ln=0,r=8,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5", InternalError},
+		{"$scrypt$ln=15,r=0,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5", InternalError},
+		{"$scrypt$ln=15,r=8,p=0$c2FsdHNhbHQ$a2V5a2V5a2V5", InternalError},
+		{"$unknown$abc", InternalError},
+		{"$2a$10$short", InternalError},
+		{"", NotAuthError},
+	}
+	for _, c := range cases {
+		u := User{PassHash: c.hash}
+		if _, err := p.Login(context.Background(), &u, "secret"); loginCode(err) != c.code {
+			t.Errorf("%q: %v", c.hash, err)
+		}
+		if u.AccessFailedCount != 0 {
+			t.Errorf("%q: counted as failed attempt", c.hash)
+		}
+	}
+}
+
+func TestLoginRehash(t *testing.T) {
+	p := testPasswords()
+	hashes := map[string]User{}
+	bcryptHash, _ := BcryptHasher{Cost: bcrypt.MinCost}.Hash("secret")
+	hashes["bcrypt"] = User{PassHash: bcryptHash}
+	hashes["legacy"] = User{PassHash: "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"}
+	for name, u := range hashes {
+		password := "secret"
+		if name == "legacy" {
+			password = "password"
+		}
+		rehashed, err := p.Login(context.Background(), &u, password)
+		if err != nil || !rehashed || !p.Current.Match(u.PassHash) {
+			t.Errorf("%s: rehashed %v, %v, %s", name, rehashed, err, u.PassHash)
+		}
+	}
+}
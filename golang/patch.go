@@ -0,0 +1,640 @@
+package golang
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"mime"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Типы содержимого запросов частичного обновления
+const (
+	MergePatchContentType = "application/merge-patch+json" // RFC 7396
+	JSONPatchContentType  = "application/json-patch+json"  // RFC 6902
+)
+
+// AnyRole - ключ PatchWhitelist для полей, которые может изменять любой авторизованный пользователь
+const AnyRole = "*"
+
+// ProtectedPatchFields - поля, которые нельзя изменить через patch ни при каком списке разрешенных полей:
+// идентификаторы и владельцы, учетные данные, права и подтверждения, счетчики входа
+var ProtectedPatchFields = []string{
+	"id", "passHash", "salt", "roles", "sessionKey", "addedDate",
+	"status", "isEmailConfirm", "isCompanyConfirm", "usersGroups", "company", "ownerId", "comapnyId", "companyId",
+	"accesFailedCnt", "lastFailedLogin",
+}
+
+// PatchWhitelist - разрешенные для изменения поля (пути по json тегам, например profile.name) по имени роли.
+// Поле разрешает и все вложенные в него поля, "*" - все поля кроме ProtectedPatchFields
+type PatchWhitelist map[string][]string
+
+func prefixed(prefix string, fields ...string) []string {
+	res := make([]string, len(fields))
+	for i := range fields {
+		res[i] = prefix + "." + fields[i]
+	}
+	return res
+}
+
+var (
+	profilePatchFields = []string{"lastName", "name", "nick", "info", "country", "region", "city", "lat", "lng", "avatarB64"}
+	addressPatchFields = []string{"country", "region", "city", "district", "microDistrict", "street", "build", "apartment", "lat", "lng", "postCode", "fullName"}
+)
+
+// Списки полей по умолчанию. Сервисы дополняют их своими ролями, например UserPatchWhitelist["admin"] = []string{"*"}
+var (
+	UserProfilePatchWhitelist = PatchWhitelist{AnyRole: profilePatchFields}
+	UserPatchWhitelist        = PatchWhitelist{AnyRole: append([]string{"email", "phone"}, prefixed("profile", profilePatchFields...)...)}
+	AddressPatchWhitelist     = PatchWhitelist{AnyRole: addressPatchFields}
+	CompanyPatchWhitelist     = PatchWhitelist{AnyRole: append([]string{"name", "description", "logo"}, prefixed("address", addressPatchFields...)...)}
+	GroupPatchWhitelist       = PatchWhitelist{AnyRole: {"name", "description", "logo"}}
+)
+
+// Fields - fields allowed for the principal (AnyRole and all roles of the principal)
+func (w PatchWhitelist) Fields(p Principal) []string {
+	res := append([]string(nil), w[AnyRole]...)
+	for _, name := range p.RoleNames {
+		res = append(res, w[name]...)
+	}
+	return res
+}
+
+// Apply - apply patch (by content type) to dst with fields allowed for the principal of the context
+func (w PatchWhitelist) Apply(ctx context.Context, dst interface{}, contentType string, patch []byte) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return EgeonError{Code: NotAuthError, Description: Errors["undefUser"].Error()}
+	}
+	return ApplyPatch(dst, contentType, patch, w.Fields(p))
+}
+
+// PatchOperation - операция JSON Patch (RFC 6902)
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	type op PatchOperation
+	if o.Op == "add" || o.Op == "replace" || o.Op == "test" {
+		// value: null допустимое значение, поэтому не опускается
+		return json.Marshal(struct {
+			op
+			Value interface{} `json:"value"`
+		}{op(o), o.Value})
+	}
+	o.Value = nil
+	return json.Marshal(op(o))
+}
+
+func badPatch(msg string) error {
+	return EgeonError{Code: IncorrectRequestParam, Description: Errors["badPatch"].Error()}.WithFields(FieldError{Field: "patch", Message: msg})
+}
+
+// decodeDocument - generic json document, numbers are json.Number
+func decodeDocument(data []byte) (interface{}, error) {
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// toDocument - DTO as generic json document
+func toDocument(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDocument(data)
+}
+
+// jsonEqual - equality of the generic documents (numbers are compared by value)
+func jsonEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if av == bv {
+			return true
+		}
+		af, err1 := av.Float64()
+		bf, err2 := bv.Float64()
+		return err1 == nil && err2 == nil && af == bf
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k := range av {
+			if v, ok := bv[k]; !ok || !jsonEqual(av[k], v) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// changedPaths - dot paths of the changed leaves of the documents
+func changedPaths(before, after interface{}, prefix string, res *[]string) {
+	bm, ok1 := before.(map[string]interface{})
+	am, ok2 := after.(map[string]interface{})
+	if !ok1 || !ok2 {
+		if !jsonEqual(before, after) {
+			*res = append(*res, prefix)
+		}
+		return
+	}
+	for _, k := range sortedKeys(bm) {
+		changedPaths(bm[k], am[k], joinPath(prefix, k), res)
+	}
+	for _, k := range sortedKeys(am) {
+		if _, ok := bm[k]; !ok {
+			changedPaths(nil, am[k], joinPath(prefix, k), res)
+		}
+	}
+}
+
+// ChangedFields - paths (by json tags) of the fields that differ in two versions of the DTO
+func ChangedFields(before, after interface{}) ([]string, error) {
+	b, err := toDocument(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := toDocument(after)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	changedPaths(b, a, "", &res)
+	return res, nil
+}
+
+func fieldAllowed(path string, allowed []string) bool {
+	under := func(f string) bool { return path == f || strings.HasPrefix(path, f+".") }
+	for _, f := range ProtectedPatchFields {
+		if under(f) {
+			return false
+		}
+	}
+	for _, f := range allowed {
+		if f == "*" || under(f) {
+			return true
+		}
+	}
+	return false
+}
+
+// commitDocument - check changed fields and decode patched document into dst
+func commitDocument(dst interface{}, before, after interface{}, allowed []string) error {
+	var changed []string
+	changedPaths(before, after, "", &changed)
+	var denied []FieldError
+	for _, path := range changed {
+		if !fieldAllowed(path, allowed) {
+			denied = append(denied, FieldError{Field: path, Message: "поле не можна змінювати"})
+		}
+	}
+	if len(denied) != 0 {
+		return EgeonError{Code: Permission, Description: Errors["permission"].Error()}.WithFields(denied...)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+	// декодируем в новое значение, чтобы удаленные поля стали нулевыми
+	v := reflect.ValueOf(dst)
+	res := reflect.New(v.Elem().Type())
+	if err = json.Unmarshal(data, res.Interface()); err != nil {
+		return badPatch(err.Error())
+	}
+	v.Elem().Set(keepHidden(v.Elem(), res.Elem()))
+	return nil
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// keepHidden - decoded value with fields that are not in JSON (json:"-", unexported) taken from orig.
+// Types with own decoder (time.Time) keep their unexported fields from decoded
+func keepHidden(orig, decoded reflect.Value) reflect.Value {
+	switch orig.Kind() {
+	case reflect.Ptr:
+		if orig.IsNil() || decoded.IsNil() {
+			return decoded
+		}
+		res := reflect.New(orig.Type().Elem())
+		res.Elem().Set(keepHidden(orig.Elem(), decoded.Elem()))
+		return res
+	case reflect.Struct:
+	default:
+		return decoded
+	}
+	t := orig.Type()
+	res := reflect.New(t).Elem()
+	if pt := reflect.PtrTo(t); pt.Implements(jsonUnmarshalerType) || pt.Implements(textUnmarshalerType) {
+		res.Set(decoded)
+	} else {
+		res.Set(orig)
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !res.Field(i).CanSet() {
+			continue
+		}
+		if f.Tag.Get("json") == "-" {
+			res.Field(i).Set(orig.Field(i))
+		} else {
+			res.Field(i).Set(keepHidden(orig.Field(i), decoded.Field(i)))
+		}
+	}
+	return res
+}
+
+func checkPatchTarget(dst interface{}) error {
+	if v := reflect.ValueOf(dst); v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return EgeonError{Code: InternalError, Description: Errors["badType"].Error()}
+	}
+	return nil
+}
+
+// mergePatch - RFC 7396 merge of the patch into target document
+func mergePatch(target, patch interface{}) interface{} {
+	pm, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	tm, ok := target.(map[string]interface{})
+	if !ok {
+		tm = make(map[string]interface{}, len(pm))
+	}
+	for k, v := range pm {
+		if v == nil {
+			delete(tm, k)
+		} else {
+			tm[k] = mergePatch(tm[k], v)
+		}
+	}
+	return tm
+}
+
+// ApplyMergePatch - apply RFC 7396 merge patch to the DTO (pointer to struct).
+// Change of the field not from allowed list is rejected with Permission error. dst is not changed on error
+func ApplyMergePatch(dst interface{}, patch []byte, allowed []string) error {
+	if err := checkPatchTarget(dst); err != nil {
+		return err
+	}
+	p, err := decodeDocument(patch)
+	if err != nil {
+		return badPatch(err.Error())
+	}
+	if _, ok := p.(map[string]interface{}); !ok {
+		return badPatch("очікується json об'єкт")
+	}
+	before, err := toDocument(dst)
+	if err != nil {
+		return err
+	}
+	after, _ := toDocument(dst)
+	return commitDocument(dst, before, mergePatch(after, p), allowed)
+}
+
+// parsePointer - RFC 6901 json pointer tokens
+func parsePointer(p string) ([]string, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+	if p[0] != '/' {
+		return nil, badPatch("не коректний шлях " + p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(tokens[i], "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+func arrayIndex(tok string, length int, insert bool) (int, error) {
+	if insert && tok == "-" {
+		return length, nil
+	}
+	i, err := strconv.Atoi(tok)
+	max := length - 1
+	if insert {
+		max = length
+	}
+	if err != nil || i < 0 || i > max || len(tok) > 1 && tok[0] == '0' {
+		return 0, badPatch("не коректний індекс масиву " + tok)
+	}
+	return i, nil
+}
+
+// pointerGet - value of the document by pointer tokens
+func pointerGet(node interface{}, tokens []string) (interface{}, error) {
+	for _, tok := range tokens {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			v, ok := n[tok]
+			if !ok {
+				return nil, badPatch("поле " + tok + " не існує")
+			}
+			node = v
+		case []interface{}:
+			i, err := arrayIndex(tok, len(n), false)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, badPatch("поле " + tok + " не існує")
+		}
+	}
+	return node, nil
+}
+
+// pointerModify - add, replace or remove value by pointer tokens. Return new node (arrays are reallocated)
+func pointerModify(node interface{}, tokens []string, op string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		if op == "remove" {
+			return nil, badPatch("неможливо видалити документ")
+		}
+		return value, nil
+	}
+	tok, last := tokens[0], len(tokens) == 1
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[tok]
+		if !last {
+			if !ok {
+				return nil, badPatch("поле " + tok + " не існує")
+			}
+			v, err := pointerModify(child, tokens[1:], op, value)
+			if err != nil {
+				return nil, err
+			}
+			n[tok] = v
+			return n, nil
+		}
+		switch {
+		case op == "add":
+			n[tok] = value
+		case !ok:
+			return nil, badPatch("поле " + tok + " не існує")
+		case op == "replace":
+			n[tok] = value
+		default:
+			delete(n, tok)
+		}
+		return n, nil
+	case []interface{}:
+		i, err := arrayIndex(tok, len(n), last && op == "add")
+		if err != nil {
+			return nil, err
+		}
+		if !last {
+			v, err := pointerModify(n[i], tokens[1:], op, value)
+			if err != nil {
+				return nil, err
+			}
+			n[i] = v
+			return n, nil
+		}
+		switch op {
+		case "add":
+			res := make([]interface{}, 0, len(n)+1)
+			res = append(append(append(res, n[:i]...), value), n[i:]...)
+			return res, nil
+		case "replace":
+			n[i] = value
+			return n, nil
+		}
+		return append(append([]interface{}(nil), n[:i]...), n[i+1:]...), nil
+	}
+	return nil, badPatch("поле " + tok + " не існує")
+}
+
+// applyOperation - apply one JSON Patch operation to the document
+func applyOperation(doc interface{}, o PatchOperation) (interface{}, error) {
+	path, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch o.Op {
+	case "add", "replace", "remove":
+		return pointerModify(doc, path, o.Op, o.Value)
+	case "test":
+		v, err := pointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonEqual(v, o.Value) {
+			return nil, EgeonError{Code: BadUpdateAttempt, Description: Errors["patchTestFailed"].Error()}
+		}
+		return doc, nil
+	case "move", "copy":
+		from, err := parsePointer(o.From)
+		if err != nil {
+			return nil, err
+		}
+		v, err := pointerGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if o.Op == "move" {
+			if o.Path == o.From {
+				return doc, nil
+			}
+			if strings.HasPrefix(o.Path, o.From+"/") {
+				return nil, badPatch("неможливо перемістити " + o.From + " в свого нащадка")
+			}
+			if doc, err = pointerModify(doc, from, "remove", nil); err != nil {
+				return nil, err
+			}
+		} else if v, err = toDocument(v); err != nil {
+			return nil, err
+		}
+		return pointerModify(doc, path, "add", v)
+	}
+	return nil, badPatch("невідома операція " + o.Op)
+}
+
+// ApplyJSONPatch - apply RFC 6902 json patch to the DTO (pointer to struct). Operations are applied atomically:
+// dst is not changed if any of them fails. Failed test operation gives BadUpdateAttempt error
+func ApplyJSONPatch(dst interface{}, patch []byte, allowed []string) error {
+	if err := checkPatchTarget(dst); err != nil {
+		return err
+	}
+	// операции разбираются по ключам: "value": null - допустимое значение, а не отсутствие value
+	var ops []map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return badPatch(err.Error())
+	}
+	before, err := toDocument(dst)
+	if err != nil {
+		return err
+	}
+	after, _ := toDocument(dst)
+	for i, raw := range ops {
+		var o PatchOperation
+		if _, ok := raw["path"]; !ok {
+			return badPatch("операція " + strconv.Itoa(i) + " не містить path")
+		}
+		for _, m := range []struct {
+			key string
+			val *string
+		}{{"op", &o.Op}, {"path", &o.Path}, {"from", &o.From}} {
+			if data, ok := raw[m.key]; ok && (json.Unmarshal(data, m.val) != nil || string(bytes.TrimSpace(data)) == "null") {
+				return badPatch("операція " + strconv.Itoa(i) + ": " + m.key + " має бути рядком")
+			}
+		}
+		if o.Op == "add" || o.Op == "replace" || o.Op == "test" {
+			value, ok := raw["value"]
+			if !ok {
+				return badPatch("операція " + strconv.Itoa(i) + " не містить value")
+			}
+			if o.Value, err = decodeDocument(value); err != nil {
+				return badPatch(err.Error())
+			}
+		}
+		if after, err = applyOperation(after, o); err != nil {
+			return err
+		}
+	}
+	return commitDocument(dst, before, after, allowed)
+}
+
+// ApplyPatch - apply patch by content type of the request: JSONPatchContentType or merge patch (application/json too)
+func ApplyPatch(dst interface{}, contentType string, patch []byte, allowed []string) error {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mt = contentType
+	}
+	switch mt {
+	case JSONPatchContentType:
+		return ApplyJSONPatch(dst, patch, allowed)
+	case MergePatchContentType, "application/json", "":
+		return ApplyMergePatch(dst, patch, allowed)
+	}
+	return EgeonError{Code: IncorrectRequestParam, Description: Errors["badPatch"].Error()}.WithFields(
+		FieldError{Field: "Content-Type", Message: "очікується " + MergePatchContentType + " або " + JSONPatchContentType})
+}
+
+func diffDocuments(before, after interface{}, path string, ops *[]PatchOperation) {
+	bm, ok1 := before.(map[string]interface{})
+	am, ok2 := after.(map[string]interface{})
+	if !ok1 || !ok2 {
+		if !jsonEqual(before, after) {
+			*ops = append(*ops, PatchOperation{Op: "replace", Path: path, Value: after})
+		}
+		return
+	}
+	for _, k := range sortedKeys(bm) {
+		p := path + "/" + escapePointer(k)
+		if v, ok := am[k]; ok {
+			diffDocuments(bm[k], v, p, ops)
+		} else {
+			*ops = append(*ops, PatchOperation{Op: "remove", Path: p})
+		}
+	}
+	for _, k := range sortedKeys(am) {
+		if _, ok := bm[k]; !ok {
+			*ops = append(*ops, PatchOperation{Op: "add", Path: path + "/" + escapePointer(k), Value: am[k]})
+		}
+	}
+}
+
+// Diff - RFC 6902 json patch that transforms before into after (versions of the same DTO).
+// Arrays are replaced entirely
+func Diff(before, after interface{}) ([]PatchOperation, error) {
+	b, err := toDocument(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := toDocument(after)
+	if err != nil {
+		return nil, err
+	}
+	ops := []PatchOperation{}
+	diffDocuments(b, a, "", &ops)
+	return ops, nil
+}
+
+func mergeDiff(before, after interface{}) (interface{}, bool) {
+	bm, ok1 := before.(map[string]interface{})
+	am, ok2 := after.(map[string]interface{})
+	if !ok1 || !ok2 {
+		return after, !jsonEqual(before, after)
+	}
+	res := make(map[string]interface{})
+	for k, bv := range bm {
+		if av, ok := am[k]; !ok {
+			res[k] = nil
+		} else if d, changed := mergeDiff(bv, av); changed {
+			res[k] = d
+		}
+	}
+	for k, av := range am {
+		if _, ok := bm[k]; !ok {
+			res[k] = av
+		}
+	}
+	return res, len(res) != 0
+}
+
+// MergeDiff - RFC 7396 merge patch that transforms before into after. Empty object if versions are equal
+func MergeDiff(before, after interface{}) ([]byte, error) {
+	b, err := toDocument(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := toDocument(after)
+	if err != nil {
+		return nil, err
+	}
+	d, changed := mergeDiff(b, a)
+	if !changed {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(d)
+}
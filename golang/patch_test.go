@@ -0,0 +1,171 @@
+package golang
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func patchUser() User {
+	return User{ID: 7, Email: "a@egeon.ua", Phone: "+380501234567",
+		Profile: UserProfile{Name: "Іван", Info: "about", City: "Київ"}}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	cases := []struct {
+		name    string
+		patch   string
+		allowed []string
+		code    uint32 // 0 - без ошибки
+		check   func(u User) bool
+	}{
+		{"null value", `[{"op":"replace","path":"/profile/info","value":null}]`, []string{"profile"}, 0,
+			func(u User) bool { return u.Profile.Info == "" && u.Profile.Name == "Іван" }},
+		{"add and remove", `[{"op":"add","path":"/profile/nick","value":"Петрович"},{"op":"remove","path":"/phone"}]`,
+			[]string{"phone", "profile.nick"}, 0,
+			func(u User) bool { return u.Profile.PatronymicName == "Петрович" && u.Phone == "" }},
+		{"test passed", `[{"op":"test","path":"/email","value":"a@egeon.ua"},{"op":"replace","path":"/email","value":"b@egeon.ua"}]`,
+			[]string{"email"}, 0, func(u User) bool { return u.Email == "b@egeon.ua" }},
+		{"test failed", `[{"op":"test","path":"/email","value":"x@egeon.ua"},{"op":"replace","path":"/email","value":"b@egeon.ua"}]`,
+			[]string{"email"}, BadUpdateAttempt, nil},
+		{"no value", `[{"op":"replace","path":"/email"}]`, []string{"*"}, IncorrectRequestParam, nil},
+		{"no path", `[{"op":"remove"}]`, []string{"*"}, IncorrectRequestParam, nil},
+		{"null path", `[{"op":"remove","path":null}]`, []string{"*"}, IncorrectRequestParam, nil},
+		{"not allowed", `[{"op":"replace","path":"/email","value":"b@egeon.ua"}]`, []string{"phone"}, Permission, nil},
+		{"not array", `{"op":"remove","path":"/phone"}`, []string{"*"}, IncorrectRequestParam, nil},
+	}
+	for _, c := range cases {
+		u := patchUser()
+		err := ApplyJSONPatch(&u, []byte(c.patch), c.allowed)
+		if c.code != 0 {
+			var e EgeonError
+			if !errors.As(err, &e) || e.Code != c.code {
+				t.Errorf("%s: got %v, want code %d", c.name, err, c.code)
+			}
+			if !reflect.DeepEqual(u, patchUser()) {
+				t.Errorf("%s: dst changed on error", c.name)
+			}
+			continue
+		}
+		if err != nil || !c.check(u) {
+			t.Errorf("%s: %v, %+v", c.name, err, u)
+		}
+	}
+}
+
+func TestPatchProtectedFields(t *testing.T) {
+	patches := []string{
+		`{"id":8}`,
+		`{"status":{"id":2}}`,
+		`{"isEmailConfirm":true}`,
+		`{"isCompanyConfirm":true}`,
+		`{"company":{"id":3}}`,
+		`{"usersGroups":[{"groupId":1}]}`,
+		`{"accesFailedCnt":0}`,
+		`{"lastFailedLogin":"2026-01-01T00:00:00Z"}`,
+		`{"roles":[{"id":1,"name":"admin"}]}`,
+	}
+	for _, p := range patches {
+		u := patchUser()
+		u.AccessFailedCount = 3
+		err := ApplyMergePatch(&u, []byte(p), []string{"*"})
+		var e EgeonError
+		if !errors.As(err, &e) || e.Code != Permission || len(e.FieldList()) == 0 {
+			t.Errorf("%s: %v", p, err)
+		}
+	}
+	g := Group{ID: 1, Name: "g", OwnerID: 1, CompanyID: 2}
+	for _, p := range []string{`{"ownerId":5}`, `{"comapnyId":5}`} {
+		if err := ApplyMergePatch(&g, []byte(p), []string{"*"}); err == nil {
+			t.Errorf("%s: group owner changed", p)
+		}
+	}
+	if err := ApplyPatch(&g, MergePatchContentType, []byte(`{"name":"new"}`), []string{"*"}); err != nil || g.Name != "new" {
+		t.Fatalf("allowed field: %v", err)
+	}
+	if err := ApplyPatch(&g, "text/plain", []byte(`{}`), []string{"*"}); err == nil {
+		t.Fatal("unknown content type accepted")
+	}
+}
+
+func TestDiffRoundTrip(t *testing.T) {
+	added := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := []struct{ before, after UserProfile }{
+		{UserProfile{}, UserProfile{}},
+		{UserProfile{Name: "Іван", City: "Київ"}, UserProfile{Name: "Іван", City: "Львів", Lat: 49.8}},
+		{UserProfile{Name: "a/b~c", Info: "x", AddedDate: added}, UserProfile{LastName: "Шевченко", AddedDate: added}},
+	}
+	for _, c := range cases {
+		ops, err := Diff(c.before, c.after)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := json.Marshal(ops)
+		got := c.before
+		if err = ApplyJSONPatch(&got, data, []string{"*"}); err != nil || !reflect.DeepEqual(got, c.after) {
+			t.Errorf("json patch %s: %+v, %v", data, got, err)
+		}
+		merge, err := MergeDiff(c.before, c.after)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = c.before
+		if err = ApplyMergePatch(&got, merge, []string{"*"}); err != nil || !reflect.DeepEqual(got, c.after) {
+			t.Errorf("merge patch %s: %+v, %v", merge, got, err)
+		}
+		if reflect.DeepEqual(c.before, c.after) && (len(ops) != 0 || string(merge) != "{}") {
+			t.Errorf("diff of equal values: %s %s", data, merge)
+		}
+	}
+}
+
+func TestPatchKeepsPassword(t *testing.T) {
+	whitelist := UserPatchWhitelist[AnyRole]
+	apply := map[string]func(u *User) error{
+		"merge": func(u *User) error { return ApplyMergePatch(u, []byte(`{"email":"x@y.z"}`), whitelist) },
+		"json": func(u *User) error {
+			return ApplyJSONPatch(u, []byte(`[{"op":"replace","path":"/email","value":"x@y.z"}]`), whitelist)
+		},
+	}
+	for name, fn := range apply {
+		u := patchUser()
+		u.PassHash, u.Salt = "$argon2id$hash", "salt"
+		if err := fn(&u); err != nil || u.Email != "x@y.z" || u.PassHash != "$argon2id$hash" || u.Salt != "salt" {
+			t.Errorf("%s: %v, %+v", name, err, u)
+		}
+	}
+}
+
+// hiddenDTO - DTO with fields that are not encoded to JSON
+type hiddenDTO struct {
+	Name    string    `json:"name"`
+	Secret  string    `json:"-"`
+	counter int       // не экспортируется
+	Date    time.Time `json:"date"`
+	Inner   struct {
+		Value  string `json:"value"`
+		Secret string `json:"-"`
+	} `json:"inner"`
+	Ptr *hiddenDTO `json:"ptr,omitempty"`
+}
+
+func TestPatchKeepsHiddenFields(t *testing.T) {
+	date := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	v := hiddenDTO{Name: "a", Secret: "s", counter: 3, Date: date, Ptr: &hiddenDTO{Name: "p", Secret: "ps"}}
+	v.Inner.Value, v.Inner.Secret = "v", "is"
+	patch := `{"name":"b","date":"2021-01-02T03:04:05Z","inner":{"value":"w"},"ptr":{"name":"q"}}`
+	if err := ApplyMergePatch(&v, []byte(patch), []string{"*"}); err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != "b" || v.Inner.Value != "w" || v.Ptr.Name != "q" || v.Date.Year() != 2021 {
+		t.Errorf("patch not applied %+v", v)
+	}
+	if v.Secret != "s" || v.counter != 3 || v.Inner.Secret != "is" || v.Ptr.Secret != "ps" {
+		t.Errorf("hidden fields lost %+v", v)
+	}
+	if err := ApplyMergePatch(&v, []byte(`{"ptr":null}`), []string{"*"}); err != nil || v.Ptr != nil || v.Secret != "s" {
+		t.Errorf("removed pointer %+v, %v", v, err)
+	}
+}
@@ -0,0 +1,334 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Action - действие над ресурсом
+type Action uint8
+
+const (
+	ActionRead Action = iota
+	ActionCreate
+	ActionUpdate
+	ActionDelete
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionRead:
+		return "read"
+	case ActionCreate:
+		return "create"
+	case ActionUpdate:
+		return "update"
+	case ActionDelete:
+		return "delete"
+	}
+	return "action" + strconv.Itoa(int(a))
+}
+
+// ActionFromMethod - action of the http method (POST - create, PUT and PATCH - update, DELETE - delete, others - read)
+func ActionFromMethod(method string) Action {
+	switch method {
+	case http.MethodPost:
+		return ActionCreate
+	case http.MethodPut, http.MethodPatch:
+		return ActionUpdate
+	case http.MethodDelete:
+		return ActionDelete
+	}
+	return ActionRead
+}
+
+// Resource - ресурс, доступ к которому проверяется: группы, в которые он входит, компания и владелец
+type Resource struct {
+	GroupIDs  []uint64
+	CompanyID uint32 // 0 - ресурс не принадлежит компании
+	OwnerID   uint32 // 0 - владелец не известен
+}
+
+// GroupResource - resource of the group itself
+func GroupResource(g Group) Resource {
+	return Resource{GroupIDs: []uint64{g.ID}, CompanyID: g.CompanyID, OwnerID: g.OwnerID}
+}
+
+// allows - right of the membership for the action
+func allows(ug UsersGroup, action Action) bool {
+	switch action {
+	case ActionRead:
+		return true
+	case ActionCreate:
+		return ug.IsCreate
+	case ActionUpdate:
+		return ug.IsUpdate
+	case ActionDelete:
+		return ug.IsDelete
+	}
+	return false
+}
+
+// MembershipSource - источник членства пользователя в группах (база данных, сервис пользователей)
+type MembershipSource interface {
+	UserGroups(ctx context.Context, userID uint32) ([]UsersGroup, error)
+}
+
+// MembershipFunc - function as MembershipSource
+type MembershipFunc func(ctx context.Context, userID uint32) ([]UsersGroup, error)
+
+func (f MembershipFunc) UserGroups(ctx context.Context, userID uint32) ([]UsersGroup, error) {
+	return f(ctx, userID)
+}
+
+// accessTable - таблица LocalCache движка прав: доступ пользователя по его идентификатору
+const accessTable uint32 = 0
+
+// maxUserDecisions - решений в кеше одного пользователя, при переполнении кеш пользователя очищается
+const maxUserDecisions = 1024
+
+// decisionKey - ключ кеша решения в пределах пользователя
+type decisionKey struct {
+	action      Action
+	groups      string
+	companyID   uint32
+	ownerID     uint32
+	userCompany uint32 // компания пользователя (читать ресурсы своей компании)
+}
+
+// userAccess - членство пользователя в группах и принятые решения.
+// Invalidate удаляет запись из кеша целиком, загрузка, которая шла в это время, изменит уже отцепленную запись
+type userAccess struct {
+	mt        sync.Mutex
+	loaded    bool
+	groups    []UsersGroup
+	decisions map[decisionKey]bool
+}
+
+// PermissionEngine - решает, может ли пользователь выполнить действие над ресурсом:
+//   - владелец ресурса (OwnerID) может все;
+//   - член группы ресурса может читать, а создавать, изменять и удалять - по флагам IsCreate, IsUpdate, IsDelete.
+//     Права группы другой компании на ресурс компании не действуют;
+//   - сотрудник компании ресурса может читать;
+//   - пользователь с одной из SuperRoles может все.
+//
+// Решения и членство в группах кешируются в LocalCache на время ttl
+type PermissionEngine struct {
+	SuperRoles []string
+
+	source MembershipSource
+	cache  *LocalCache
+	mt     sync.Mutex // создание и удаление записей пользователей
+}
+
+// NewPermissionEngine - engine with memberships from source and cache life time ttl (0 - until Invalidate)
+func NewPermissionEngine(source MembershipSource, ttl time.Duration, superRoles ...string) *PermissionEngine {
+	return &PermissionEngine{
+		SuperRoles: superRoles,
+		source:     source,
+		cache:      GetNewNamedCache("permissions", ttl, accessTable),
+	}
+}
+
+// access - cached access of the user, new empty one if it is not cached or expired
+func (e *PermissionEngine) access(userID uint32) *userAccess {
+	if a, ok := e.cache.GetItem(accessTable, userID).(*userAccess); ok {
+		return a
+	}
+	e.mt.Lock()
+	defer e.mt.Unlock()
+	if a, ok := e.cache.GetItem(accessTable, userID).(*userAccess); ok {
+		return a
+	}
+	a := &userAccess{decisions: make(map[decisionKey]bool)}
+	e.cache.StoreItem(accessTable, userID, a)
+	return a
+}
+
+// Invalidate - forget cached memberships and decisions of the user (call it after change of the user groups)
+func (e *PermissionEngine) Invalidate(userID uint32) {
+	e.mt.Lock()
+	e.cache.DeleteItem(accessTable, userID)
+	e.mt.Unlock()
+}
+
+// memberships - groups of the user, loaded once per cache entry. Must be called with a.mt locked
+func (e *PermissionEngine) memberships(ctx context.Context, a *userAccess, userID uint32) ([]UsersGroup, error) {
+	if a.loaded {
+		return a.groups, nil
+	}
+	groups, err := e.source.UserGroups(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	a.groups, a.loaded = groups, true
+	return groups, nil
+}
+
+func groupsKey(ids []uint64) string {
+	sorted := append([]uint64(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	parts := make([]string, len(sorted))
+	for i := range sorted {
+		parts[i] = strconv.FormatUint(sorted[i], 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Decide - can principal do the action with the resource
+func (e *PermissionEngine) Decide(ctx context.Context, p Principal, action Action, res Resource) (bool, error) {
+	if p.ID == 0 {
+		return false, nil
+	}
+	for _, role := range e.SuperRoles {
+		if p.HasRole(role) {
+			return true, nil
+		}
+	}
+	if res.OwnerID != 0 && res.OwnerID == p.ID {
+		return true, nil
+	}
+	key := decisionKey{
+		action:      action,
+		groups:      groupsKey(res.GroupIDs),
+		companyID:   res.CompanyID,
+		ownerID:     res.OwnerID,
+		userCompany: p.CompanyID,
+	}
+	a := e.access(p.ID)
+	a.mt.Lock()
+	defer a.mt.Unlock()
+	if allowed, ok := a.decisions[key]; ok {
+		return allowed, nil
+	}
+	allowed, err := e.decide(ctx, a, p, action, res)
+	if err != nil {
+		return false, err
+	}
+	if len(a.decisions) >= maxUserDecisions {
+		a.decisions = make(map[decisionKey]bool)
+	}
+	a.decisions[key] = allowed
+	return allowed, nil
+}
+
+func (e *PermissionEngine) decide(ctx context.Context, a *userAccess, p Principal, action Action, res Resource) (bool, error) {
+	if action == ActionRead && res.CompanyID != 0 && res.CompanyID == p.CompanyID {
+		return true, nil
+	}
+	if len(res.GroupIDs) == 0 {
+		return false, nil
+	}
+	groups, err := e.memberships(ctx, a, p.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, ug := range groups {
+		if ug.UserID != p.ID || !allows(ug, action) {
+			continue
+		}
+		if res.CompanyID != 0 && ug.Group.CompanyID != 0 && ug.Group.CompanyID != res.CompanyID {
+			continue
+		}
+		for _, id := range res.GroupIDs {
+			if ug.Group.ID == id {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Can - can principal of the context do the action with the resource
+func (e *PermissionEngine) Can(ctx context.Context, action Action, res Resource) (bool, error) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return false, nil
+	}
+	return e.Decide(ctx, p, action, res)
+}
+
+var libPermissions atomic.Value
+
+type permissionsHolder struct {
+	*PermissionEngine
+}
+
+// SetPermissionEngine - set engine used by Can
+func SetPermissionEngine(e *PermissionEngine) {
+	libPermissions.Store(permissionsHolder{e})
+}
+
+// GetPermissionEngine - permission engine of the library or nil
+func GetPermissionEngine() *PermissionEngine {
+	h, _ := libPermissions.Load().(permissionsHolder)
+	return h.PermissionEngine
+}
+
+// Can - can principal of the context do the action with the resource (engine from SetPermissionEngine)
+func Can(ctx context.Context, action Action, res Resource) (bool, error) {
+	e := GetPermissionEngine()
+	if e == nil {
+		return false, EgeonError{Code: MethodNotImplemented, Description: Errors["notImplement"].Error()}
+	}
+	return e.Can(ctx, action, res)
+}
+
+// ResourceFunc - resource of the request
+type ResourceFunc func(r *http.Request) (Resource, error)
+
+// GinResourceFunc - the same as ResourceFunc for gin handlers (path parameters are available in gin context)
+type GinResourceFunc func(c *gin.Context) (Resource, error)
+
+// checkPermission - http status and error if principal of the request can not do action of the method with resource
+func checkPermission(e *PermissionEngine, r *http.Request, res Resource, err error) (int, error) {
+	ctx := r.Context()
+	if err != nil {
+		return errorStatus(ctx, err)
+	}
+	if _, ok := PrincipalFromContext(ctx); !ok {
+		return http.StatusUnauthorized, EgeonError{Code: NotAuthError, Description: Errors["undefUser"].Error()}
+	}
+	allowed, err := e.Can(ctx, ActionFromMethod(r.Method), res)
+	if err != nil {
+		GetLogger().Error(ctx, "permission check failed", "error", err)
+		return http.StatusInternalServerError, EgeonError{Code: InternalError, Description: Errors["internal"].Error()}
+	}
+	if !allowed {
+		return http.StatusForbidden, EgeonError{Code: Permission, Description: Errors["permission"].Error()}
+	}
+	return 0, nil
+}
+
+// PermissionMiddleware - gin middleware that reject request with 403 if user can not do action of the method
+// (see ActionFromMethod) with the resource. Must be placed after ParseHeaderMiddleware
+func PermissionMiddleware(e *PermissionEngine, resource GinResourceFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		res, err := resource(c)
+		if status, err := checkPermission(e, c.Request, res, err); err != nil {
+			c.AbortWithStatusJSON(status, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+// PermissionHTTPMiddleware - the same as PermissionMiddleware for net/http. Must be wrapped by ParseHTTPHeaderMiddleware
+func PermissionHTTPMiddleware(e *PermissionEngine, resource ResourceFunc, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, err := resource(r)
+		if status, err := checkPermission(e, r, res, err); err != nil {
+			writeJSON(w, status, err)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
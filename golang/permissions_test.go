@@ -0,0 +1,171 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testMemberships - источник членства, который можно менять и задерживать
+type testMemberships struct {
+	mt     sync.Mutex
+	groups map[uint32][]UsersGroup
+	calls  int
+	gate   chan struct{} // не nil - загрузка ждет закрытия
+	err    error
+}
+
+func (s *testMemberships) UserGroups(ctx context.Context, userID uint32) ([]UsersGroup, error) {
+	s.mt.Lock()
+	s.calls++
+	groups := append([]UsersGroup(nil), s.groups[userID]...)
+	gate := s.gate
+	err := s.err
+	s.mt.Unlock()
+	if gate != nil {
+		<-gate
+	}
+	return groups, err
+}
+
+func (s *testMemberships) set(userID uint32, groups ...UsersGroup) {
+	s.mt.Lock()
+	s.groups[userID] = groups
+	s.mt.Unlock()
+}
+
+func TestPermissionDecide(t *testing.T) {
+	src := &testMemberships{groups: map[uint32][]UsersGroup{
+		1: {{UserID: 1, Group: Group{ID: 10, CompanyID: 5}, IsUpdate: true}},
+		2: {{UserID: 2, Group: Group{ID: 20}}},
+	}}
+	e := NewPermissionEngine(src, time.Minute, "admin")
+	ctx := context.Background()
+	cases := []struct {
+		name   string
+		p      Principal
+		action Action
+		res    Resource
+		want   bool
+	}{
+		{"anonymous", Principal{}, ActionRead, Resource{OwnerID: 0}, false},
+		{"owner", Principal{ID: 3}, ActionDelete, Resource{OwnerID: 3}, true},
+		{"super role", Principal{ID: 4, RoleNames: []string{"admin"}}, ActionDelete, Resource{GroupIDs: []uint64{99}}, true},
+		{"member update", Principal{ID: 1}, ActionUpdate, Resource{GroupIDs: []uint64{10}, CompanyID: 5}, true},
+		{"member delete", Principal{ID: 1}, ActionDelete, Resource{GroupIDs: []uint64{10}}, false},
+		{"group of other company", Principal{ID: 1}, ActionRead, Resource{GroupIDs: []uint64{10}, CompanyID: 6}, false},
+		{"reader", Principal{ID: 2}, ActionRead, Resource{GroupIDs: []uint64{30, 20}}, true},
+		{"reader update", Principal{ID: 2}, ActionUpdate, Resource{GroupIDs: []uint64{20}}, false},
+		{"employee", Principal{ID: 2, CompanyID: 7}, ActionRead, Resource{CompanyID: 7}, true},
+		{"employee update", Principal{ID: 2, CompanyID: 7}, ActionUpdate, Resource{CompanyID: 7}, false},
+		// то же решение для пользователя другой компании не берется из кеша
+		{"other company user", Principal{ID: 2, CompanyID: 8}, ActionRead, Resource{CompanyID: 7}, false},
+	}
+	for _, c := range cases {
+		got, err := e.Decide(ctx, c.p, c.action, c.res)
+		if err != nil || got != c.want {
+			t.Errorf("%s: %v, %v", c.name, got, err)
+		}
+	}
+}
+
+func TestPermissionInvalidate(t *testing.T) {
+	src := &testMemberships{groups: map[uint32][]UsersGroup{1: {{UserID: 1, Group: Group{ID: 10}}}}}
+	e := NewPermissionEngine(src, 0)
+	ctx := context.Background()
+	p := Principal{ID: 1}
+	res := Resource{GroupIDs: []uint64{10}}
+	if ok, _ := e.Decide(ctx, p, ActionRead, res); !ok {
+		t.Fatal("member can not read")
+	}
+	src.set(1)
+	if ok, _ := e.Decide(ctx, p, ActionRead, res); !ok {
+		t.Fatal("decision is not cached")
+	}
+	e.Invalidate(1)
+	if ok, _ := e.Decide(ctx, p, ActionRead, res); ok {
+		t.Fatal("decision is cached after Invalidate")
+	}
+	if e.cache.GetItem(accessTable, uint32(2)) != nil {
+		t.Fatal("access of other user is created")
+	}
+}
+
+func TestPermissionInvalidateDuringLoad(t *testing.T) {
+	src := &testMemberships{groups: map[uint32][]UsersGroup{1: {{UserID: 1, Group: Group{ID: 10}}}}, gate: make(chan struct{})}
+	e := NewPermissionEngine(src, time.Minute)
+	ctx := context.Background()
+	p := Principal{ID: 1}
+	res := Resource{GroupIDs: []uint64{10}}
+	done := make(chan bool)
+	go func() {
+		ok, _ := e.Decide(ctx, p, ActionRead, res)
+		done <- ok
+	}()
+	// загрузка началась со старым членством, затем пользователя исключили из группы
+	for {
+		src.mt.Lock()
+		calls := src.calls
+		src.mt.Unlock()
+		if calls == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	src.set(1)
+	e.Invalidate(1)
+	src.mt.Lock()
+	close(src.gate)
+	src.gate = nil
+	src.mt.Unlock()
+	<-done
+	if ok, _ := e.Decide(ctx, p, ActionRead, res); ok {
+		t.Fatal("memberships loaded before Invalidate are cached")
+	}
+}
+
+func TestPermissionDecisionsBounded(t *testing.T) {
+	src := &testMemberships{groups: map[uint32][]UsersGroup{}}
+	e := NewPermissionEngine(src, time.Minute)
+	p := Principal{ID: 1}
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < maxUserDecisions; i++ {
+				e.Decide(context.Background(), p, ActionRead, Resource{GroupIDs: []uint64{uint64(g*maxUserDecisions + i)}})
+			}
+		}(g)
+	}
+	wg.Wait()
+	a := e.access(1)
+	a.mt.Lock()
+	defer a.mt.Unlock()
+	if len(a.decisions) > maxUserDecisions {
+		t.Fatalf("%d decisions cached", len(a.decisions))
+	}
+	if src.calls != 1 {
+		t.Fatalf("memberships loaded %d times", src.calls)
+	}
+}
+
+func TestCheckPermissionHidesSourceError(t *testing.T) {
+	src := &testMemberships{groups: map[uint32][]UsersGroup{}, err: errors.New("pq: connection refused")}
+	e := NewPermissionEngine(src, time.Minute)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(context.WithValue(r.Context(), PrincipalKey, Principal{ID: 1}))
+	status, err := checkPermission(e, r, Resource{GroupIDs: []uint64{1}}, nil)
+	var ee EgeonError
+	if status != http.StatusInternalServerError || !errors.As(err, &ee) || ee.Description != Errors["internal"].Error() {
+		t.Fatalf("%d %v", status, err)
+	}
+	status, err = checkPermission(e, r, Resource{}, errors.New("sql: no rows"))
+	if status != http.StatusInternalServerError || !errors.As(err, &ee) || ee.Description != Errors["internal"].Error() {
+		t.Fatalf("resource error: %d %v", status, err)
+	}
+}
@@ -0,0 +1,207 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	retry "github.com/hashicorp/go-retryablehttp"
+)
+
+type principalKeyType string
+type fullUserKeyType string
+
+// PrincipalKey - ключ, по которому в контексте будет сохранен Principal пользователя
+var PrincipalKey principalKeyType
+
+var fullUserKey fullUserKeyType
+
+// Principal - compact projection of the user that is sent between services
+func (u *User) Principal() Principal {
+	p := Principal{
+		ID:         u.ID,
+		Email:      u.Email,
+		CompanyID:  u.Company.ID,
+		SessionKey: u.SessionKey,
+		ExpireDate: u.ExpireDate,
+	}
+	if len(u.Roles) != 0 {
+		p.RoleIDs = make([]uint32, len(u.Roles))
+		p.RoleNames = make([]string, len(u.Roles))
+		for i := range u.Roles {
+			p.RoleIDs[i] = u.Roles[i].ID
+			p.RoleNames[i] = u.Roles[i].Name
+		}
+	}
+	return p
+}
+
+// User - restore user from principal. Only fields of principal are filled
+func (p Principal) User() User {
+	u := User{
+		ID:         p.ID,
+		Email:      p.Email,
+		Company:    Company{ID: p.CompanyID},
+		SessionKey: p.SessionKey,
+		ExpireDate: p.ExpireDate,
+	}
+	if len(p.RoleIDs) != 0 {
+		u.Roles = make([]Role, len(p.RoleIDs))
+		for i := range p.RoleIDs {
+			u.Roles[i].ID = p.RoleIDs[i]
+			if i < len(p.RoleNames) {
+				u.Roles[i].Name = p.RoleNames[i]
+			}
+		}
+	}
+	return u
+}
+
+// HasRole - check that principal has role with name
+func (p Principal) HasRole(name string) bool {
+	for i := range p.RoleNames {
+		if p.RoleNames[i] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePrincipal - parse principal from the user header.
+// Services of the old versions send full User, in this case it will be projected to Principal
+func parsePrincipal(data []byte) (Principal, error) {
+	var p Principal
+	if err := p.UnmarshalJSON(data); err != nil {
+		return p, err
+	}
+	if len(p.RoleIDs) == 0 && p.CompanyID == 0 {
+		var u User
+		if err := u.UnmarshalJSON(data); err == nil && (len(u.Roles) != 0 || u.Company.ID != 0) {
+			return u.Principal(), nil
+		}
+	}
+	return p, nil
+}
+
+// PrincipalFromContext - principal of the request. User in context is projected if it is newer than principal:
+// ParseHeader saves principal.User() with the principal, other user means the service replaced it after ParseHeader
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, pOk := ctx.Value(PrincipalKey).(Principal)
+	u, uOk := ctx.Value(UserKey).(User)
+	if uOk && (!pOk || !reflect.DeepEqual(u, p.User())) {
+		return u.Principal(), true
+	}
+	return p, pOk
+}
+
+// SendLegacyUserFields - DoRequest adds company and roles in the User format to the principal header,
+// so services of the old versions that parse User do not lose them. Will be removed in the next release
+var SendLegacyUserFields = true
+
+// legacyRef - id and name of the company or role in the User format
+type legacyRef struct {
+	ID   uint32 `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// principalHeader - Principal with legacy fields of the User
+type principalHeader struct {
+	ID         uint32      `json:"id"`
+	Email      string      `json:"email"`
+	CompanyID  uint32      `json:"companyId,omitempty"`
+	RoleIDs    []uint32    `json:"roleIds,omitempty"`
+	RoleNames  []string    `json:"roleNames,omitempty"`
+	SessionKey SessionKey  `json:"sessionKey,omitempty"`
+	ExpireDate time.Time   `json:"expired,omitempty"`
+	Company    *legacyRef  `json:"company,omitempty"`
+	Roles      []legacyRef `json:"roles,omitempty"`
+}
+
+// principalHeaderJSON - value of the UserHeaderKey header
+func principalHeaderJSON(p Principal) ([]byte, error) {
+	if !SendLegacyUserFields {
+		return p.MarshalJSON()
+	}
+	h := principalHeader{
+		ID:         p.ID,
+		Email:      p.Email,
+		CompanyID:  p.CompanyID,
+		RoleIDs:    p.RoleIDs,
+		RoleNames:  p.RoleNames,
+		SessionKey: p.SessionKey,
+		ExpireDate: p.ExpireDate,
+	}
+	if p.CompanyID != 0 {
+		h.Company = &legacyRef{ID: p.CompanyID}
+	}
+	for i := range p.RoleIDs {
+		ref := legacyRef{ID: p.RoleIDs[i]}
+		if i < len(p.RoleNames) {
+			ref.Name = p.RoleNames[i]
+		}
+		h.Roles = append(h.Roles, ref)
+	}
+	return json.Marshal(&h)
+}
+
+// UserLoader - load full user (profile, company, roles and so on) by principal. For example from auth service
+type UserLoader func(ctx context.Context, p Principal) (User, error)
+
+var userLoader atomic.Value
+
+// SetUserLoader - set loader used by FullUser
+func SetUserLoader(loader UserLoader) {
+	userLoader.Store(loader)
+}
+
+// lazyUser - full user of the request, loaded once on demand
+type lazyUser struct {
+	once sync.Once
+	user User
+	err  error
+}
+
+// withLazyUser - add holder of the full user to the context. Called by ParseHeader
+func withLazyUser(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fullUserKey, &lazyUser{})
+}
+
+// FullUser - full user of the request loaded by UserLoader (see SetUserLoader) once per request.
+// Without loader user restored from principal is returned
+func FullUser(ctx context.Context) (User, error) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return User{}, Errors["undefUser"]
+	}
+	loader, _ := userLoader.Load().(UserLoader)
+	if loader == nil {
+		return p.User(), nil
+	}
+	lazy, ok := ctx.Value(fullUserKey).(*lazyUser)
+	if !ok {
+		return loader(ctx, p)
+	}
+	lazy.once.Do(func() {
+		lazy.user, lazy.err = loader(ctx, p)
+	})
+	return lazy.user, lazy.err
+}
+
+// AuthServiceLoader - loader that request full user of principal from auth service by GET userURL
+func AuthServiceLoader(client *retry.Client, userURL url.URL) UserLoader {
+	return func(ctx context.Context, p Principal) (User, error) {
+		ctx = context.WithValue(ctx, PrincipalKey, p)
+		data, err := DoRequest(ctx, client, http.MethodGet, userURL, nil)
+		if err != nil {
+			return User{}, err
+		}
+		var user User
+		err = user.UnmarshalJSON(data)
+		return user, err
+	}
+}
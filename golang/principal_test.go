@@ -0,0 +1,83 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	retry "github.com/hashicorp/go-retryablehttp"
+)
+
+func TestPrincipalHeaderLegacyFields(t *testing.T) {
+	p := Principal{ID: 5, Email: "a@b.c", CompanyID: 3, RoleIDs: []uint32{1, 2}, RoleNames: []string{"admin", "user"}, SessionKey: "key"}
+	data, err := principalHeaderJSON(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// сервис старой версии читает User
+	var old User
+	if err = old.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	if old.ID != 5 || old.Company.ID != 3 || len(old.Roles) != 2 || old.Roles[0].Name != "admin" || old.SessionKey != "key" {
+		t.Fatalf("old service user %+v", old)
+	}
+	parsed, err := parsePrincipal(data)
+	if err != nil || !reflect.DeepEqual(parsed, p) {
+		t.Fatalf("parsed %+v, %v", parsed, err)
+	}
+
+	SendLegacyUserFields = false
+	defer func() { SendLegacyUserFields = true }()
+	data, _ = principalHeaderJSON(p)
+	old = User{}
+	old.UnmarshalJSON(data)
+	if len(old.Roles) != 0 || old.Company.ID != 0 {
+		t.Fatalf("legacy fields are sent: %s", data)
+	}
+}
+
+func TestPrincipalFromContext(t *testing.T) {
+	p := Principal{ID: 1, RoleNames: []string{"user"}, RoleIDs: []uint32{1}}
+	ctx := context.WithValue(context.Background(), PrincipalKey, p)
+	ctx = context.WithValue(ctx, UserKey, p.User())
+	if got, ok := PrincipalFromContext(ctx); !ok || !reflect.DeepEqual(got, p) {
+		t.Fatalf("principal %+v", got)
+	}
+	newer := p.User()
+	newer.Roles = append(newer.Roles, Role{ID: 2, Name: "admin"})
+	ctx = context.WithValue(ctx, UserKey, newer)
+	if got, _ := PrincipalFromContext(ctx); !got.HasRole("admin") {
+		t.Fatalf("newer user is ignored: %+v", got)
+	}
+	if _, ok := PrincipalFromContext(context.Background()); ok {
+		t.Fatal("principal in empty context")
+	}
+}
+
+func TestDoRequestNewerUser(t *testing.T) {
+	var got Principal
+	srv := httptest.NewServer(ParseHTTPHeaderMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = PrincipalFromContext(r.Context())
+	})))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	client := retry.NewClient()
+	client.Logger = nil
+
+	p := Principal{ID: 1, CompanyID: 0}
+	ctx := context.WithValue(context.Background(), PrincipalKey, p)
+	ctx = context.WithValue(ctx, UserKey, p.User())
+	user := p.User()
+	user.Company.ID = 9
+	ctx = context.WithValue(ctx, UserKey, user)
+	if _, err := DoRequest(ctx, client, http.MethodGet, *u, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 1 || got.CompanyID != 9 {
+		t.Fatalf("downstream principal %+v", got)
+	}
+}
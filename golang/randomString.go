@@ -4,22 +4,47 @@ import (
 	"encoding/base32"
 	"encoding/binary"
 	"math/rand"
+	"sync"
 	"time"
 	"unsafe"
 )
 
 const RAND_SIZE = 32
 
-var randArray [RAND_SIZE]byte
-
-// GenerateRandom - выдает случайную строку
+// GenerateRandom - выдает случайную строку из букв длины RAND_SIZE (см. QuickRandom).
+// Не криптографически стойкая, для токенов и ключей используйте GenerateSecureRandom
 func GenerateRandom() string {
-	return QuickRandom(32)
+	return QuickRandom(RAND_SIZE)
+}
+
+// GenerateSecureRandom - криптографически стойкая случайная строка из букв длины RAND_SIZE
+func GenerateSecureRandom() (string, error) {
+	return SecureRandom(AlphabetLetters, RAND_SIZE)
+}
+
+// Быстрый генератор на основе math/rand. НЕ использовать для токенов, ключей сессий и паролей
+var (
+	quickMt  sync.Mutex
+	quickSrc = rand.NewSource(time.Now().UnixNano())
+)
+
+func quickInt63() int64 {
+	quickMt.Lock()
+	defer quickMt.Unlock()
+	return quickSrc.Int63()
+}
+
+func quickUint64() uint64 {
+	quickMt.Lock()
+	defer quickMt.Unlock()
+	return quickSrc.(rand.Source64).Uint64()
 }
 
+// SimplestRandom - быстрая НЕ криптографически стойкая случайная строка
 func SimplestRandom() string {
+	var randArray [RAND_SIZE]byte
 	for i := 0; i < RAND_SIZE/8; i++ {
-		binary.BigEndian.PutUint64(randArray[i*8:i*8+8], rand.Uint64())
+		binary.BigEndian.PutUint64(randArray[i*8:i*8+8], quickUint64())
 	}
 	return base32.StdEncoding.EncodeToString(randArray[:])
 }
@@ -31,13 +56,13 @@ const (
 	letterIdxMax  = 63 / letterIdxBits   // # of letter indices fitting in 63 bits
 )
 
+// QuickRandom - быстрая НЕ криптографически стойкая случайная строка из букв длины n
 func QuickRandom(n int) string {
-	src := rand.NewSource(time.Now().UnixNano())
 	b := make([]byte, n)
-	// A src.Int63() generates 63 random bits, enough for letterIdxMax characters!
-	for i, cache, remain := n-1, src.Int63(), letterIdxMax; i >= 0; {
+	// A quickInt63() generates 63 random bits, enough for letterIdxMax characters!
+	for i, cache, remain := n-1, quickInt63(), letterIdxMax; i >= 0; {
 		if remain == 0 {
-			cache, remain = src.Int63(), letterIdxMax
+			cache, remain = quickInt63(), letterIdxMax
 		}
 		if idx := int(cache & letterIdxMask); idx < len(letterBytes) {
 			b[i] = letterBytes[idx]
@@ -0,0 +1,248 @@
+package golang
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RedactedMark - заменяет секретные значения при выводе в лог
+const RedactedMark = "[REDACTED]"
+
+func redactString(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return RedactedMark
+}
+
+// Redactable - DTO that contains secret fields
+type Redactable interface {
+	// RedactedValue - copy of the value without secret fields
+	RedactedValue() interface{}
+}
+
+// Redacted - copy of user without password hash, salt and session key
+func (u User) Redacted() User {
+	u.PassHash = ""
+	u.Salt = ""
+	u.SessionKey = ""
+	return u
+}
+
+func (u User) RedactedValue() interface{} { return u.Redacted() }
+
+// Redacted - copy of token without token value
+func (t APIToken) Redacted() APIToken {
+	t.Token = ""
+	return t
+}
+
+func (t APIToken) RedactedValue() interface{} { return t.Redacted() }
+
+// Redacted - copy of principal without session key
+func (p Principal) Redacted() Principal {
+	p.SessionKey = ""
+	return p
+}
+
+func (p Principal) RedactedValue() interface{} { return p.Redacted() }
+
+// Redacted - copy of the log record without session key
+func (l UserLog) Redacted() UserLog {
+	l.SessionKey = ""
+	return l
+}
+
+func (l UserLog) RedactedValue() interface{} { return l.Redacted() }
+
+var redactableType = reflect.TypeOf((*Redactable)(nil)).Elem()
+
+// secretTypes - cache of mayHaveSecrets by type
+var secretTypes sync.Map
+
+// mayHaveSecrets - values of type t can contain Redactable values (directly, in fields, elements or interfaces)
+func mayHaveSecrets(t reflect.Type) bool {
+	if res, ok := secretTypes.Load(t); ok {
+		return res.(bool)
+	}
+	secretTypes.Store(t, true) // для рекурсивных типов, пока проверка не завершена
+	res := false
+	switch t.Kind() {
+	case reflect.Interface:
+		res = true
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		res = mayHaveSecrets(t.Elem())
+	case reflect.Map:
+		res = mayHaveSecrets(t.Key()) || mayHaveSecrets(t.Elem())
+	case reflect.Struct:
+		res = t.Implements(redactableType)
+		for i := 0; i < t.NumField() && !res; i++ {
+			if f := t.Field(i); f.PkgPath == "" {
+				res = mayHaveSecrets(f.Type)
+			}
+		}
+	}
+	secretTypes.Store(t, res)
+	return res
+}
+
+// redactReflect - copy of v with secrets removed from all Redactable values inside.
+// RedactedValue must return value of the same type
+func redactReflect(v reflect.Value) reflect.Value {
+	if !v.IsValid() || !mayHaveSecrets(v.Type()) {
+		return v
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.New(v.Type()).Elem()
+		res.Set(redactReflect(v.Elem()))
+		return res
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.New(v.Type().Elem())
+		res.Elem().Set(redactReflect(v.Elem()))
+		return res
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(redactReflect(v.Index(i)))
+		}
+		return res
+	case reflect.Array:
+		res := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(redactReflect(v.Index(i)))
+		}
+		return res
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			res.SetMapIndex(redactReflect(iter.Key()), redactReflect(iter.Value()))
+		}
+		return res
+	case reflect.Struct:
+		res := reflect.New(v.Type()).Elem()
+		res.Set(v)
+		if r, ok := v.Interface().(Redactable); ok {
+			if redacted := reflect.ValueOf(r.RedactedValue()); redacted.Type() == v.Type() {
+				res.Set(redacted)
+			}
+		}
+		for i := 0; i < res.NumField(); i++ {
+			if f := res.Type().Field(i); f.PkgPath == "" && mayHaveSecrets(f.Type) {
+				res.Field(i).Set(redactReflect(res.Field(i)))
+			}
+		}
+		return res
+	}
+	return v
+}
+
+// redactValue - remove secrets from v: Redactable values are replaced by RedactedValue at any depth
+// (slices, maps, pointers, fields and embedded structs)
+func redactValue(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return redactReflect(reflect.ValueOf(v)).Interface()
+}
+
+// PublicJSON - encode v for outward-facing response, secret fields of DTO are omitted
+func PublicJSON(v interface{}) ([]byte, error) {
+	return json.Marshal(redactValue(v))
+}
+
+// PublicJSONResponse - answer with v encoded by PublicJSON
+func PublicJSONResponse(c *gin.Context, code int, v interface{}) {
+	data, err := PublicJSON(v)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, EgeonError{Code: InternalError, Description: err.Error()})
+		return
+	}
+	c.Data(code, "application/json; charset=utf-8", data)
+}
+
+// Типы без методов форматирования, чтобы избежать рекурсии при выводе
+type userView User
+type apiTokenView APIToken
+type principalView Principal
+type userLogView UserLog
+
+// formatVerb - restore format string of the verb with flags
+func formatVerb(f fmt.State, verb rune) string {
+	var b strings.Builder
+	b.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		fmt.Fprintf(&b, "%d", w)
+	}
+	if p, ok := f.Precision(); ok {
+		fmt.Fprintf(&b, ".%d", p)
+	}
+	b.WriteRune(verb)
+	return b.String()
+}
+
+// Format - log-safe formatting, secrets are replaced by RedactedMark
+func (u User) Format(f fmt.State, verb rune) {
+	u.PassHash = redactString(u.PassHash)
+	u.Salt = redactString(u.Salt)
+	u.SessionKey = SessionKey(redactString(string(u.SessionKey)))
+	fmt.Fprintf(f, formatVerb(f, verb), userView(u))
+}
+
+func (u User) String() string {
+	return fmt.Sprintf("%+v", u)
+}
+
+// Format - log-safe formatting, secrets are replaced by RedactedMark
+func (t APIToken) Format(f fmt.State, verb rune) {
+	t.Token = redactString(t.Token)
+	fmt.Fprintf(f, formatVerb(f, verb), apiTokenView(t))
+}
+
+func (t APIToken) String() string {
+	return fmt.Sprintf("%+v", t)
+}
+
+// Format - log-safe formatting, secrets are replaced by RedactedMark
+func (p Principal) Format(f fmt.State, verb rune) {
+	p.SessionKey = SessionKey(redactString(string(p.SessionKey)))
+	fmt.Fprintf(f, formatVerb(f, verb), principalView(p))
+}
+
+func (p Principal) String() string {
+	return fmt.Sprintf("%+v", p)
+}
+
+// Format - log-safe formatting, secrets are replaced by RedactedMark
+func (l UserLog) Format(f fmt.State, verb rune) {
+	l.SessionKey = SessionKey(redactString(string(l.SessionKey)))
+	fmt.Fprintf(f, formatVerb(f, verb), userLogView(l))
+}
+
+func (l UserLog) String() string {
+	return fmt.Sprintf("%+v", l)
+}
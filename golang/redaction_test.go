@@ -0,0 +1,74 @@
+package golang
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type accountResponse struct {
+	User
+	Tokens  map[string]APIToken `json:"tokens"`
+	Members []*User             `json:"members"`
+	Extra   interface{}         `json:"extra"`
+	private User
+}
+
+func TestPublicJSONNested(t *testing.T) {
+	secret := User{ID: 1, Email: "a@b.c", PassHash: "hash", Salt: "salt", SessionKey: "session-secret"}
+	other := secret
+	cases := []struct {
+		name string
+		v    interface{}
+	}{
+		{"user", secret},
+		{"pointer", &secret},
+		{"slice of pointers", []*User{&secret, nil}},
+		{"map", map[string]User{"a": secret}},
+		{"array", [2]User{secret}},
+		{"page", UserPage{Items: []User{secret}}},
+		{"interface items", Page{Items: []interface{}{secret, APIToken{Token: "token-secret"}}}},
+		{"embedded", accountResponse{
+			User:    secret,
+			Tokens:  map[string]APIToken{"t": {Token: "token-secret"}},
+			Members: []*User{&other},
+			Extra:   map[string]interface{}{"u": []Principal{{ID: 1, SessionKey: "session-secret"}}},
+			private: secret,
+		}},
+	}
+	for _, c := range cases {
+		data, err := PublicJSON(c.v)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		for _, s := range []string{"hash", "salt\"", "session-secret", "token-secret"} {
+			if strings.Contains(string(data), s) {
+				t.Errorf("%s: %s leaked in %s", c.name, s, data)
+			}
+		}
+	}
+	if secret.SessionKey != "session-secret" || other.SessionKey != "session-secret" {
+		t.Fatal("original value changed")
+	}
+}
+
+func TestUserJSONRoundTrip(t *testing.T) {
+	u := User{ID: 1, PassHash: "hash", Salt: "pepper"}
+	easy, _ := u.MarshalJSON()
+	std, _ := json.Marshal(u)
+	for _, data := range [][]byte{easy, std} {
+		// между сервисами и в кешах пользователь передается полностью
+		var got User
+		if err := got.UnmarshalJSON(data); err != nil || got.PassHash != u.PassHash || got.Salt != u.Salt {
+			t.Errorf("%s decoded as %+v: %v", data, got.Redacted(), err)
+		}
+	}
+	public, _ := PublicJSON(u)
+	if strings.Contains(string(public), "hash") || strings.Contains(string(public), "pepper") {
+		t.Errorf("password in public %s", public)
+	}
+	if s := fmt.Sprintf("%v %+v", u, &u); strings.Contains(s, "hash") || strings.Contains(s, "pepper") {
+		t.Errorf("password in %s", s)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
+	"time"
 
 	retry "github.com/hashicorp/go-retryablehttp"
 )
@@ -34,45 +36,121 @@ func appendDefaultErrorHandler(client *retry.Client) {
 	}
 }
 
+// appendRetryMetricsHook - count retries of the request per target host.
+// RequestLogHook of the client is called after counting
+func appendRetryMetricsHook(client *retry.Client) {
+	next := client.RequestLogHook
+	client.RequestLogHook = func(l retry.Logger, req *http.Request, attempt int) {
+		if attempt > 0 {
+			DefaultMetrics.OutboundRetries.Inc(req.URL.Host)
+		}
+		if next != nil {
+			next(l, req, attempt)
+		}
+	}
+}
+
+// appendStatusHook - remember status code of the last response (retryablehttp closes it when retries are exhausted).
+// ResponseLogHook of the client is called after
+func appendStatusHook(client *retry.Client, status *int) {
+	next := client.ResponseLogHook
+	client.ResponseLogHook = func(l retry.Logger, resp *http.Response) {
+		*status = resp.StatusCode
+		if next != nil {
+			next(l, resp)
+		}
+	}
+}
+
 type RequestEditorFn func(ctx context.Context, req *retry.Request) error
 
+// requestClient - copy of the shared client for one request with logger bound to the request context.
+// The shared client is not changed, so DoRequest is safe for concurrent use
+func requestClient(ctx context.Context, client *retry.Client) *retry.Client {
+	c := &retry.Client{
+		HTTPClient:      client.HTTPClient,
+		Logger:          client.Logger,
+		RetryWaitMin:    client.RetryWaitMin,
+		RetryWaitMax:    client.RetryWaitMax,
+		RetryMax:        client.RetryMax,
+		RequestLogHook:  client.RequestLogHook,
+		ResponseLogHook: client.ResponseLogHook,
+		CheckRetry:      client.CheckRetry,
+		Backoff:         client.Backoff,
+		ErrorHandler:    client.ErrorHandler,
+	}
+	if c.HTTPClient == nil {
+		c.HTTPClient = http.DefaultClient
+	}
+	if l, ok := c.Logger.(retryLogger); ok {
+		c.Logger = l.withContext(ctx)
+	}
+	return c
+}
+
 // DoRequest - create request and read answer
 // method can be GET, POST, PUT, DELETE (http method)
-// user in context is required
+// user in context is required, only its Principal is sent
 // reqBody - can be nil
 func DoRequest(ctx context.Context, client *retry.Client, method string, reqURL url.URL, reqBody []byte, reqEditors ...RequestEditorFn) ([]byte, error) {
 	req, err := retry.NewRequest(method, reqURL.String(), reqBody)
 	if err != nil {
 		return nil, err
 	}
-	user, _ := ctx.Value(UserKey).(User)
-	user.UsersGroups = nil
+	ctx, span := GetTracer().Start(ctx, "HTTP "+method+" "+reqURL.Host, SpanKindClient)
+	defer span.End()
+	req = req.WithContext(ctx)
+	span.SetAttribute("http.method", method)
+	span.SetAttribute("http.url", reqURL.Redacted())
+	principal, _ := PrincipalFromContext(ctx)
 	reqID, _ := ctx.Value(RequestID).(string)
 	if len(reqID) == 0 {
-		reqID = FormRequestID(&user)
+		reqID = NewRequestID()
 	}
 	allowedRole, _ := ctx.Value(AllowedRoleKey).(string)
-	userJSON, _ := json.Marshal(&user)
+	userJSON, _ := principalHeaderJSON(principal)
 	sign := CreateSignature([]byte(os.Getenv(EgeonSecretKeyEnviron)), userJSON)
 	req.Header.Add(SignatureHeaderKey, sign)
 	req.Header.Add(UserHeaderKey, string(userJSON))
 	req.Header.Add(RequestIDHeaderKey, reqID)
 	req.Header.Add(AllowedRoleHeaderKey, allowedRole)
 	req.Header.Add("Content-Type", "application/json")
+	span.SetAttribute(RequestIDSpanAttribute, reqID)
+	InjectSpanContext(req.Header, span.SpanContext())
 	for i := range reqEditors {
 		if err := reqEditors[i](ctx, req); err != nil {
+			span.SetError(err)
 			return nil, err
 		}
 	}
 
+	client = requestClient(ctx, client)
 	if client.ErrorHandler == nil {
 		appendDefaultErrorHandler(client)
 	}
+	appendRetryMetricsHook(client)
+	lastStatus := 0
+	appendStatusHook(client, &lastStatus)
+	start := time.Now()
 	resp, err := client.Do(req)
+	DefaultMetrics.OutboundDuration.Observe(time.Since(start).Seconds(), reqURL.Host, method)
 	if err != nil {
+		status := "error"
+		if lastStatus != 0 {
+			status = strconv.Itoa(lastStatus)
+			span.SetAttribute("http.status_code", lastStatus)
+		}
+		DefaultMetrics.Outbound.Inc(reqURL.Host, method, status)
+		span.SetError(err)
+		GetLogger().Error(ctx, "request failed", "method", method, "url", reqURL.Redacted(), "error", err)
 		return nil, EgeonError{Code: InternalError, Description: "Request failed " + " error " + err.Error()}
 	}
 	defer resp.Body.Close()
+	DefaultMetrics.Outbound.Inc(reqURL.Host, method, strconv.Itoa(resp.StatusCode))
+	span.SetAttribute("http.status_code", resp.StatusCode)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetError(errors.New(resp.Status))
+	}
 	data, err := io.ReadAll(resp.Body)
 	if resp.StatusCode >= http.StatusMultipleChoices {
 		return nil, errors.New(string(data))
@@ -0,0 +1,177 @@
+package golang
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// crockford - алфавит ULID (Crockford base32)
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// RequestIDLen - length of request ID (ULID)
+const RequestIDLen = 26
+
+var crockfordIndex [256]byte
+
+func init() {
+	for i := range crockfordIndex {
+		crockfordIndex[i] = 0xFF
+	}
+	for i := 0; i < len(crockford); i++ {
+		crockfordIndex[crockford[i]] = byte(i)
+		crockfordIndex[crockford[i]|0x20] = byte(i) // lower case
+	}
+}
+
+// ulidGenerator - монотонный генератор ULID. В пределах одной миллисекунды случайная часть увеличивается на 1
+type ulidGenerator struct {
+	mt     sync.Mutex
+	lastMs uint64
+	hi     uint16 // старшие 16 бит случайной части
+	lo     uint64 // младшие 64 бита случайной части
+}
+
+var requestIDs ulidGenerator
+
+func (g *ulidGenerator) next(now time.Time) [16]byte {
+	ms := uint64(now.UnixNano() / int64(time.Millisecond))
+	g.mt.Lock()
+	if ms <= g.lastMs {
+		ms = g.lastMs
+		g.lo++
+		if g.lo == 0 {
+			g.hi++
+		}
+	} else {
+		var entropy [10]byte
+		if _, err := rand.Read(entropy[:]); err != nil {
+			// без crypto/rand идентификатор остается уникальным, но становится предсказуемым
+			binary.BigEndian.PutUint16(entropy[:2], uint16(quickUint64()))
+			binary.BigEndian.PutUint64(entropy[2:], quickUint64())
+		}
+		g.hi = binary.BigEndian.Uint16(entropy[:2])
+		g.lo = binary.BigEndian.Uint64(entropy[2:])
+		g.lastMs = ms
+	}
+	var id [16]byte
+	binary.BigEndian.PutUint16(id[0:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(id[2:6], uint32(ms))
+	binary.BigEndian.PutUint16(id[6:8], g.hi)
+	binary.BigEndian.PutUint64(id[8:], g.lo)
+	g.mt.Unlock()
+	return id
+}
+
+func encodeULID(id [16]byte) string {
+	var dst [RequestIDLen]byte
+	// 128 бит кодируются в 26 символов по 5 бит, первый символ содержит только 3 бита
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	for i := RequestIDLen - 1; i >= 0; i-- {
+		dst[i] = crockford[lo&0x1F]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(dst[:])
+}
+
+// NewRequestID - generate sortable collision-resistant request ID (ULID)
+// IDs generated by one process are strictly increasing
+func NewRequestID() string {
+	return encodeULID(requestIDs.next(time.Now()))
+}
+
+// ValidRequestID - check that id is a correct ULID
+func ValidRequestID(id string) bool {
+	if len(id) != RequestIDLen || id[0] > '7' {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if crockfordIndex[id[i]] == 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// RequestIDTime - time when request ID was generated
+func RequestIDTime(id string) (time.Time, bool) {
+	if !ValidRequestID(id) {
+		return time.Time{}, false
+	}
+	var ms uint64
+	for i := 0; i < 10; i++ {
+		ms = ms<<5 | uint64(crockfordIndex[id[i]])
+	}
+	return time.Unix(0, int64(ms)*int64(time.Millisecond)), true
+}
+
+// LegacyRequestID - check that id has format of FormRequestID before ULID (UnixNano:email:sessionKey).
+// Такие идентификаторы еще приходят от сервисов старых версий
+func LegacyRequestID(id string) bool {
+	parts := strings.SplitN(id, ":", 3)
+	if len(parts) != 3 || len(parts[0]) == 0 {
+		return false
+	}
+	for i := 0; i < len(parts[0]); i++ {
+		if parts[0][i] < '0' || parts[0][i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// checkRequestID - request ID of the request: id itself for ULID, new one for empty or legacy id.
+// Legacy id contains email and session key so it is never forwarded. ok is false if id is malformed
+func checkRequestID(ctx context.Context, id string) (reqID string, ok bool) {
+	switch {
+	case len(id) == 0:
+		return NewRequestID(), true
+	case ValidRequestID(id):
+		return id, true
+	case LegacyRequestID(id):
+		// только время: email и ключ сессии не должны попасть в журнал
+		reqID = NewRequestID()
+		GetLogger().Warn(ctx, "legacy request ID replaced", "requestTime", id[:strings.IndexByte(id, ':')], "requestID", reqID)
+		return reqID, true
+	}
+	return "", false
+}
+
+func badRequestID() EgeonError {
+	return EgeonError{Code: IncorrectRequestParam, Description: Errors["badReqID"].Error()}
+}
+
+// RequestIDMiddleware - assign request ID when RequestIDHeaderKey is missing and echo it in the response.
+// Legacy IDs (see LegacyRequestID) are replaced by new ones with warning, request with malformed ID is rejected.
+// ID is saved in context of the request. Place it before ParseHeaderMiddleware
+func RequestIDMiddleware(c *gin.Context) {
+	reqID, ok := checkRequestID(c.Request.Context(), c.GetHeader(RequestIDHeaderKey))
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, badRequestID())
+		return
+	}
+	c.Header(RequestIDHeaderKey, reqID)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), RequestID, reqID))
+	c.Next()
+}
+
+// RequestIDHTTPMiddleware - the same as RequestIDMiddleware for net/http
+func RequestIDHTTPMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqID, ok := checkRequestID(r.Context(), r.Header.Get(RequestIDHeaderKey))
+		if !ok {
+			writeJSON(w, http.StatusBadRequest, badRequestID())
+			return
+		}
+		w.Header().Set(RequestIDHeaderKey, reqID)
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), RequestID, reqID)))
+	})
+}
@@ -0,0 +1,123 @@
+package golang
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewRequestIDMonotonic(t *testing.T) {
+	start := time.Now().Add(-time.Millisecond)
+	prev := ""
+	for i := 0; i < 10000; i++ {
+		id := NewRequestID()
+		if !ValidRequestID(id) {
+			t.Fatalf("invalid id %s", id)
+		}
+		if id <= prev {
+			t.Fatalf("%s is not greater than %s", id, prev)
+		}
+		prev = id
+	}
+	at, ok := RequestIDTime(prev)
+	if !ok || at.Before(start) || at.After(time.Now()) {
+		t.Fatalf("time of id %v", at)
+	}
+}
+
+func TestULIDOverflow(t *testing.T) {
+	g := ulidGenerator{lastMs: 1, hi: 0, lo: ^uint64(0)}
+	id := g.next(time.Unix(0, 0))
+	if g.hi != 1 || g.lo != 0 || encodeULID(id) != "0000000001000G000000000000" {
+		t.Fatalf("carry to high part: %s", encodeULID(id))
+	}
+}
+
+func TestValidRequestID(t *testing.T) {
+	cases := []struct {
+		id           string
+		valid, legal bool
+	}{
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", true, false},
+		{"01arz3ndektsv4rrffq69g5fav", true, false},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", true, false},
+		{"8ZZZZZZZZZZZZZZZZZZZZZZZZZ", false, false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FA", false, false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAU", false, false},
+		{"1617181920212223242:user@mail.com:sessionKey", false, true},
+		{"1617181920212223242::", false, true},
+		{"161718x:user@mail.com:key", false, false},
+		{":user@mail.com:key", false, false},
+		{"1617181920212223242:user@mail.com", false, false},
+		{"", false, false},
+	}
+	for _, c := range cases {
+		if ValidRequestID(c.id) != c.valid || LegacyRequestID(c.id) != c.legal {
+			t.Errorf("%q: valid %v, legacy %v", c.id, ValidRequestID(c.id), LegacyRequestID(c.id))
+		}
+	}
+}
+
+func TestRequestIDHTTPMiddleware(t *testing.T) {
+	var seen string
+	h := RequestIDHTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = r.Context().Value(RequestID).(string)
+	}))
+	legacy := "1617181920212223242:user@mail.com:key"
+	cases := []struct {
+		header string
+		status int
+	}{
+		{"", http.StatusOK},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", http.StatusOK},
+		{legacy, http.StatusOK},
+		{"garbage", http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		seen = ""
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if len(c.header) != 0 {
+			r.Header.Set(RequestIDHeaderKey, c.header)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != c.status {
+			t.Errorf("%q: status %d", c.header, w.Code)
+			continue
+		}
+		if r.Header.Get(RequestIDHeaderKey) != c.header {
+			t.Errorf("%q: incoming header changed", c.header)
+		}
+		if c.status != http.StatusOK {
+			continue
+		}
+		// устаревший ID заменяется новым: email и ключ сессии не передаются дальше
+		if echo := w.Header().Get(RequestIDHeaderKey); echo != seen || !ValidRequestID(echo) || len(c.header) != 0 && c.header != legacy && echo != c.header {
+			t.Errorf("%q: context %q, response %q", c.header, seen, echo)
+		}
+	}
+}
+
+func TestParseHeaderRequestID(t *testing.T) {
+	userJSON, _ := User{ID: 1}.MarshalJSON()
+	sign := CreateSignature([]byte(os.Getenv(EgeonSecretKeyEnviron)), userJSON)
+	var got string
+	h := RequestIDHTTPMiddleware(ParseHTTPHeaderMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = r.Context().Value(RequestID).(string)
+	})))
+	for _, id := range []string{"", "1617181920212223242:user@mail.com:key"} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(UserHeaderKey, string(userJSON))
+		r.Header.Set(SignatureHeaderKey, sign)
+		if len(id) != 0 {
+			r.Header.Set(RequestIDHeaderKey, id)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if want := w.Header().Get(RequestIDHeaderKey); got != want || !ValidRequestID(got) {
+			t.Errorf("%q: handler got %q, response %q", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	retry "github.com/hashicorp/go-retryablehttp"
+)
+
+func counterValue(c CounterVec, labelValues ...string) float64 {
+	return c.m.get(labelValues).value.load()
+}
+
+func TestDoRequestRetryHooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusServiceUnavailable, EgeonError{Code: ServiceWorkError, Description: "busy"})
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	var mt sync.Mutex
+	attempts := 0
+	client := retry.NewClient()
+	client.Logger = nil
+	client.RetryMax = 2
+	client.RetryWaitMin, client.RetryWaitMax = time.Millisecond, time.Millisecond
+	client.RequestLogHook = func(_ retry.Logger, _ *http.Request, _ int) {
+		mt.Lock()
+		attempts++
+		mt.Unlock()
+	}
+	retries := counterValue(DefaultMetrics.OutboundRetries, u.Host)
+	failed := counterValue(DefaultMetrics.Outbound, u.Host, http.MethodGet, "503")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := DoRequest(context.Background(), client, http.MethodGet, *u, nil); err == nil {
+				t.Error("error expected")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if attempts != 12 {
+		t.Errorf("user hook called %d times, want 12", attempts)
+	}
+	if got := counterValue(DefaultMetrics.OutboundRetries, u.Host) - retries; got != 8 {
+		t.Errorf("retries %v, want 8", got)
+	}
+	if got := counterValue(DefaultMetrics.Outbound, u.Host, http.MethodGet, "503") - failed; got != 4 {
+		t.Errorf("503 requests %v, want 4", got)
+	}
+	if client.ErrorHandler != nil || client.ResponseLogHook != nil {
+		t.Error("shared client changed")
+	}
+}
+
+func TestNamedCacheMetrics(t *testing.T) {
+	c := GetNewNamedCache("testCache", 0, 1)
+	hits := counterValue(DefaultMetrics.Cache, "testCache", "hit")
+	misses := counterValue(DefaultMetrics.Cache, "testCache", "miss")
+	c.StoreItem(1, "k", 1)
+	c.GetItem(1, "k")
+	c.GetItem(1, "missing")
+	if got := counterValue(DefaultMetrics.Cache, "testCache", "hit") - hits; got != 1 {
+		t.Errorf("hits %v", got)
+	}
+	if got := counterValue(DefaultMetrics.Cache, "testCache", "miss") - misses; got != 1 {
+		t.Errorf("misses %v", got)
+	}
+}
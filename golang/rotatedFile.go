@@ -0,0 +1,118 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ErrFileClosed - write to already closed file
+var ErrFileClosed = errors.New("file already closed")
+
+// RotatedFile - append only file of lines with rotation by size.
+// Safe for concurrent use
+type RotatedFile struct {
+	mt         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	size       int64
+	f          *os.File
+	closed     bool
+}
+
+// OpenRotatedFile - open (or create) file for appending lines
+// maxSize - size in bytes after which file will be rotated (0 - never rotate)
+// maxBackups - how many rotated files path.1, path.2 ... will be kept
+func OpenRotatedFile(path string, maxSize int64, maxBackups int) (*RotatedFile, error) {
+	rf := &RotatedFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *RotatedFile) open() error {
+	f, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	rf.f = f
+	rf.size = info.Size()
+	return nil
+}
+
+// rotate - rename current file to path.1 and open new one.
+// Файл открывается заново даже если переименование не удалось, чтобы запись не прекращалась.
+// Если открыть файл не удалось, rf.f остается nil и WriteLine повторит попытку
+func (rf *RotatedFile) rotate() error {
+	err := rf.f.Close()
+	rf.f = nil
+	if rf.maxBackups <= 0 {
+		if e := os.Remove(rf.path); e != nil && !os.IsNotExist(e) && err == nil {
+			err = e
+		}
+	} else {
+		os.Remove(fmt.Sprintf("%s.%d", rf.path, rf.maxBackups))
+		for i := rf.maxBackups - 1; i > 0; i-- {
+			if e := os.Rename(fmt.Sprintf("%s.%d", rf.path, i), fmt.Sprintf("%s.%d", rf.path, i+1)); e != nil && !os.IsNotExist(e) && err == nil {
+				err = e
+			}
+		}
+		if e := os.Rename(rf.path, rf.path+".1"); e != nil && !os.IsNotExist(e) && err == nil {
+			err = e
+		}
+	}
+	if e := rf.open(); e != nil {
+		return e
+	}
+	return err
+}
+
+// WriteLine - write data and new line symbol to the file
+func (rf *RotatedFile) WriteLine(data []byte) error {
+	rf.mt.Lock()
+	defer rf.mt.Unlock()
+	if rf.closed {
+		return ErrFileClosed
+	}
+	if rf.f == nil {
+		if err := rf.open(); err != nil {
+			return err
+		}
+	}
+	if rf.maxSize > 0 && rf.size > 0 && rf.size+int64(len(data))+1 > rf.maxSize {
+		if err := rf.rotate(); err != nil {
+			if rf.f == nil {
+				return err
+			}
+			// файл открыт заново, строка не теряется, но старые копии могли не сдвинуться
+			GetLogger().Error(context.Background(), "file rotation failed", "path", rf.path, "error", err)
+		}
+	}
+	line := make([]byte, 0, len(data)+1)
+	line = append(append(line, data...), '\n')
+	n, err := rf.f.Write(line)
+	rf.size += int64(n)
+	return err
+}
+
+// Close - close the file
+func (rf *RotatedFile) Close() error {
+	rf.mt.Lock()
+	defer rf.mt.Unlock()
+	rf.closed = true
+	if rf.f == nil {
+		return nil
+	}
+	err := rf.f.Close()
+	rf.f = nil
+	return err
+}
@@ -0,0 +1,85 @@
+package golang
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/bits"
+)
+
+// Alphabet - набор символов, из которых формируется случайная строка
+type Alphabet string
+
+// Алфавиты для токенов, ключей сессий и кодов подтверждения
+const (
+	AlphabetBase32    Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	AlphabetBase62    Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	AlphabetURLBase64 Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+	AlphabetNumeric   Alphabet = "0123456789"
+	AlphabetLetters   Alphabet = letterBytes
+)
+
+// Длины по умолчанию
+const (
+	TokenLen      = 43 // ~256 бит в base62
+	SessionKeyLen = 32 // ~190 бит в base62
+)
+
+var errBadAlphabet = errors.New("alphabet must contain from 2 to 256 symbols")
+
+// SecureRandom - криптографически стойкая случайная строка длины n из символов alphabet.
+// Символы выбираются равновероятно (значения байт за пределами кратного длине алфавита отбрасываются).
+// Безопасна для одновременного вызова из нескольких горутин
+func SecureRandom(alphabet Alphabet, n int) (string, error) {
+	size := len(alphabet)
+	if size < 2 || size > 256 {
+		return "", errBadAlphabet
+	}
+	if n <= 0 {
+		return "", nil
+	}
+	// Маска на ближайшую степень двойки, не меньшую size
+	mask := byte(1<<uint(bits.Len8(uint8(size-1))) - 1)
+	res := make([]byte, n)
+	// С запасом, чтобы в большинстве случаев хватило одного чтения
+	buf := make([]byte, n+n/2+8)
+	for i := 0; i < n; {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if idx := int(b & mask); idx < size {
+				res[i] = alphabet[idx]
+				i++
+				if i == n {
+					break
+				}
+			}
+		}
+	}
+	return string(res), nil
+}
+
+// MustSecureRandom - same as SecureRandom but panics on error (only if system random source is broken)
+func MustSecureRandom(alphabet Alphabet, n int) string {
+	s, err := SecureRandom(alphabet, n)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// NewToken - new base62 token for API tokens
+func NewToken() (string, error) {
+	return SecureRandom(AlphabetBase62, TokenLen)
+}
+
+// NewSessionKey - new secure session key
+func NewSessionKey() (SessionKey, error) {
+	key, err := SecureRandom(AlphabetBase62, SessionKeyLen)
+	return SessionKey(key), err
+}
+
+// NewNumericCode - numeric code with n digits (for example for password restore by sms)
+func NewNumericCode(n int) (string, error) {
+	return SecureRandom(AlphabetNumeric, n)
+}
@@ -0,0 +1,77 @@
+package golang
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func onlyFrom(s string, alphabet Alphabet) bool {
+	for _, r := range s {
+		if !strings.ContainsRune(string(alphabet), r) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGenerateRandomLetters(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if s := GenerateRandom(); len(s) != RAND_SIZE || !onlyFrom(s, AlphabetLetters) {
+			t.Fatalf("GenerateRandom %q", s)
+		}
+		s, err := GenerateSecureRandom()
+		if err != nil || len(s) != RAND_SIZE || !onlyFrom(s, AlphabetLetters) {
+			t.Fatalf("GenerateSecureRandom %q, %v", s, err)
+		}
+	}
+}
+
+func TestSecureRandomAlphabets(t *testing.T) {
+	cases := []struct {
+		alphabet Alphabet
+		n        int
+		err      bool
+	}{
+		{AlphabetBase62, TokenLen, false},
+		{AlphabetNumeric, 6, false},
+		{AlphabetBase32, 1, false},
+		{AlphabetURLBase64, 100, false},
+		{AlphabetNumeric, 0, false},
+		{"a", 10, true},
+		{"", 10, true},
+	}
+	for _, c := range cases {
+		s, err := SecureRandom(c.alphabet, c.n)
+		if (err != nil) != c.err || !c.err && (len(s) != c.n || !onlyFrom(s, c.alphabet)) {
+			t.Errorf("%q %d: %q, %v", c.alphabet, c.n, s, err)
+		}
+	}
+}
+
+func TestSecureRandomDistribution(t *testing.T) {
+	counts := make(map[rune]int)
+	var mt sync.Mutex
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := MustSecureRandom(AlphabetNumeric, 10000)
+			mt.Lock()
+			for _, r := range s {
+				counts[r]++
+			}
+			mt.Unlock()
+		}()
+	}
+	wg.Wait()
+	for r, c := range counts {
+		if c < 3400 || c > 4600 {
+			t.Errorf("digit %c: %d of 40000", r, c)
+		}
+	}
+	if len(counts) != 10 {
+		t.Errorf("digits %d", len(counts))
+	}
+}
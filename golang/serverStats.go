@@ -0,0 +1,189 @@
+package golang
+
+import (
+	"math"
+	"net/http"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+const (
+	latencyBins      = 80                     // Количество интервалов гистограммы времени ответа
+	latencyBinBase   = 100 * time.Microsecond // Верхняя граница первого интервала
+	latencyBinFactor = 1.2                    // Каждый следующий интервал в latencyBinFactor раз больше предыдущего
+	latencySlot      = 10 * time.Second       // Шаг скользящего окна
+	latencySlots     = 90                     // 15 минут
+)
+
+// latencyWindows - окна, за которые считаются перцентили
+var latencyWindows = []struct {
+	name  string
+	slots int
+}{
+	{"1m", int(time.Minute / latencySlot)},
+	{"5m", int(5 * time.Minute / latencySlot)},
+	{"15m", int(15 * time.Minute / latencySlot)},
+}
+
+var latencyBounds [latencyBins]time.Duration
+
+func init() {
+	b := float64(latencyBinBase)
+	for i := range latencyBounds {
+		latencyBounds[i] = time.Duration(b)
+		b *= latencyBinFactor
+	}
+}
+
+func latencyBin(d time.Duration) int {
+	if d <= latencyBinBase {
+		return 0
+	}
+	i := int(math.Ceil(math.Log(float64(d)/float64(latencyBinBase)) / math.Log(latencyBinFactor)))
+	if i >= latencyBins {
+		return latencyBins - 1
+	}
+	return i
+}
+
+// latencySlotHist - гистограмма времени ответа за один шаг окна.
+// При переходе на новый шаг слот не обнуляется, а заменяется новой гистограммой (CAS указателя),
+// поэтому параллельные Observe не теряют значения
+type latencySlotHist struct {
+	epoch  int64 // номер шага (время / latencySlot), к которому относятся counts
+	counts [latencyBins]uint64
+}
+
+type methodCounters struct {
+	success uint64
+	failed  uint64
+	classes [4]uint64 // 2xx, 3xx, 4xx, 5xx
+}
+
+var statsMethods = [...]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, "OTHER"}
+
+func methodIndex(method string) int {
+	for i := 0; i < len(statsMethods)-1; i++ {
+		if statsMethods[i] == method {
+			return i
+		}
+	}
+	return len(statsMethods) - 1
+}
+
+// RequestStats - lock-free статистика запросов сервиса
+type RequestStats struct {
+	totalTime uint64 // суммарное время ответа в миллисекундах
+	methods   [len(statsMethods)]methodCounters
+	slots     [latencySlots]unsafe.Pointer // *latencySlotHist
+}
+
+// NewRequestStats - create empty statistics of requests
+func NewRequestStats() *RequestStats {
+	return &RequestStats{}
+}
+
+// Observe - register finished request. Request is failed if it was aborted or status is 5xx
+func (rs *RequestStats) Observe(method string, status int, aborted bool, d time.Duration) {
+	m := &rs.methods[methodIndex(method)]
+	if aborted || status >= http.StatusInternalServerError {
+		atomic.AddUint64(&m.failed, 1)
+	} else {
+		atomic.AddUint64(&m.success, 1)
+	}
+	if class := status/100 - 2; class >= 0 && class < len(m.classes) {
+		atomic.AddUint64(&m.classes[class], 1)
+	}
+	atomic.AddUint64(&rs.totalTime, uint64(d.Milliseconds()))
+
+	epoch := time.Now().UnixNano() / int64(latencySlot)
+	ptr := &rs.slots[epoch%latencySlots]
+	for {
+		old := atomic.LoadPointer(ptr)
+		slot := (*latencySlotHist)(old)
+		if slot != nil && slot.epoch >= epoch {
+			if slot.epoch == epoch {
+				atomic.AddUint64(&slot.counts[latencyBin(d)], 1)
+			}
+			return
+		}
+		atomic.CompareAndSwapPointer(ptr, old, unsafe.Pointer(&latencySlotHist{epoch: epoch}))
+	}
+}
+
+// latency - перцентили за последние slots шагов
+func (rs *RequestStats) latency(now int64, slots int) LatencyStats {
+	var hist [latencyBins]uint64
+	var total uint64
+	for e := now - int64(slots) + 1; e <= now; e++ {
+		slot := (*latencySlotHist)(atomic.LoadPointer(&rs.slots[e%latencySlots]))
+		if slot == nil || slot.epoch != e {
+			continue
+		}
+		for i := range hist {
+			c := atomic.LoadUint64(&slot.counts[i])
+			hist[i] += c
+			total += c
+		}
+	}
+	res := LatencyStats{Count: total}
+	if total == 0 {
+		return res
+	}
+	percentile := func(p float64) time.Duration {
+		rank := uint64(math.Ceil(p * float64(total)))
+		var cum uint64
+		for i := range hist {
+			cum += hist[i]
+			if cum >= rank {
+				return latencyBounds[i]
+			}
+		}
+		return latencyBounds[latencyBins-1]
+	}
+	res.P50 = percentile(0.50)
+	res.P95 = percentile(0.95)
+	res.P99 = percentile(0.99)
+	return res
+}
+
+// Fill - заполняет счетчики запросов, среднее время ответа и перцентили в status
+func (rs *RequestStats) Fill(status *ServerStatus) {
+	status.Methods = make(map[string]MethodStats, len(statsMethods))
+	status.SuccesReqCnt, status.FaileReqCnt = 0, 0
+	for i, name := range statsMethods {
+		m := &rs.methods[i]
+		ms := MethodStats{
+			SuccessCnt: atomic.LoadUint64(&m.success),
+			FailedCnt:  atomic.LoadUint64(&m.failed),
+			Status2xx:  atomic.LoadUint64(&m.classes[0]),
+			Status3xx:  atomic.LoadUint64(&m.classes[1]),
+			Status4xx:  atomic.LoadUint64(&m.classes[2]),
+			Status5xx:  atomic.LoadUint64(&m.classes[3]),
+		}
+		status.SuccesReqCnt += ms.SuccessCnt
+		status.FaileReqCnt += ms.FailedCnt
+		switch name {
+		case http.MethodGet:
+			status.FaileGetCnt = ms.FailedCnt
+		case http.MethodPost:
+			status.FailePostCnt = ms.FailedCnt
+		case http.MethodPut:
+			status.FailePutCnt = ms.FailedCnt
+		case http.MethodDelete:
+			status.FaileDelCnt = ms.FailedCnt
+		}
+		if ms.SuccessCnt+ms.FailedCnt != 0 {
+			status.Methods[name] = ms
+		}
+	}
+	if all := status.SuccesReqCnt + status.FaileReqCnt; all != 0 {
+		status.MiddleReqTime = int64(atomic.LoadUint64(&rs.totalTime) / all)
+	}
+	now := time.Now().UnixNano() / int64(latencySlot)
+	status.Latency = make(map[string]LatencyStats, len(latencyWindows))
+	for _, w := range latencyWindows {
+		status.Latency[w.name] = rs.latency(now, w.slots)
+	}
+}
@@ -0,0 +1,71 @@
+package golang
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRequestStatsConcurrentObserve(t *testing.T) {
+	rs := NewRequestStats()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				status := http.StatusOK
+				if j%10 == 0 {
+					status = http.StatusInternalServerError
+				}
+				rs.Observe(http.MethodGet, status, false, time.Duration(i+1)*time.Millisecond)
+			}
+		}(i)
+	}
+	wg.Wait()
+	var status ServerStatus
+	rs.Fill(&status)
+	if status.SuccesReqCnt != 7200 || status.FaileReqCnt != 800 || status.FaileGetCnt != 800 {
+		t.Fatalf("success %d, failed %d", status.SuccesReqCnt, status.FaileReqCnt)
+	}
+	if got := status.Latency["1m"].Count; got != 8000 {
+		t.Fatalf("latency count %d, want 8000", got)
+	}
+	if p99 := status.Latency["1m"].P99; p99 < 8*time.Millisecond || p99 > 10*time.Millisecond {
+		t.Fatalf("p99 %v", p99)
+	}
+}
+
+func TestLatencyBin(t *testing.T) {
+	cases := []time.Duration{0, latencyBinBase, time.Millisecond, time.Second, time.Hour}
+	for _, d := range cases {
+		i := latencyBin(d)
+		if d > latencyBounds[i] && i != latencyBins-1 || i > 0 && d <= latencyBounds[i-1] {
+			t.Errorf("%v in bin %d (%v)", d, i, latencyBounds[i])
+		}
+	}
+}
+
+func TestGetServerStatsHandler(t *testing.T) {
+	rs := NewRequestStats()
+	var connected int32
+	mux := http.NewServeMux()
+	mux.Handle("/status", GetServerStatsHandler(ServerStatus{}, &connected, func() error { return nil }, rs))
+	mux.HandleFunc("/missing", http.NotFound)
+	h := RequestStatsHTTPMiddleware(rs, mux)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/missing", nil))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	var status ServerStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if m := status.Methods[http.MethodPost]; m.SuccessCnt != 1 || m.Status4xx != 1 {
+		t.Fatalf("POST stats %+v", m)
+	}
+}
@@ -0,0 +1,256 @@
+package golang
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionStore - хранилище сессий пользователей
+type SessionStore interface {
+	// Save - create or replace session
+	Save(ctx context.Context, s Session) error
+	// Get - return active session by key. Return Errors["badSession"] if session is absent or expired
+	Get(ctx context.Context, key SessionKey) (Session, error)
+	// Refresh - move ExpireDate of the active session to now + ttl and update LastActivity.
+	// Session that was revoked or expired is not restored, Errors["badSession"] is returned
+	Refresh(ctx context.Context, key SessionKey, ttl time.Duration) (Session, error)
+	// Revoke - delete one session
+	Revoke(ctx context.Context, key SessionKey) error
+	// RevokeAll - delete all sessions of the user
+	RevokeAll(ctx context.Context, userID uint32) error
+	// List - active sessions of the user
+	List(ctx context.Context, userID uint32) ([]Session, error)
+}
+
+// CreateSession - create session for the user with life time ttl and save it to the store
+// SessionKey, ExpireDate and LastActivity of the user are updated
+func CreateSession(ctx context.Context, store SessionStore, user *User, r *http.Request, ttl time.Duration) (Session, error) {
+	key, err := NewSessionKey()
+	if err != nil {
+		return Session{}, err
+	}
+	now := time.Now()
+	s := Session{
+		Key:          key,
+		UserID:       user.ID,
+		AddedDate:    now,
+		LastActivity: now,
+		ExpireDate:   now.Add(ttl),
+	}
+	if r != nil {
+		s.IP = clientIP(r)
+		s.UserAgent = r.UserAgent()
+	}
+	if err := store.Save(ctx, s); err != nil {
+		return Session{}, err
+	}
+	user.SessionKey = s.Key
+	user.ExpireDate = s.ExpireDate
+	user.LastActivity = s.LastActivity
+	return s, nil
+}
+
+// RefreshSession - sliding expiry. Move ExpireDate of the session to now + ttl and update LastActivity.
+// Revoked session is not restored (see SessionStore.Refresh)
+func RefreshSession(ctx context.Context, store SessionStore, key SessionKey, ttl time.Duration) (Session, error) {
+	return store.Refresh(ctx, key, ttl)
+}
+
+func sessionError() EgeonError {
+	return EgeonError{Code: NotAuthError, Description: Errors["badSession"].Error()}
+}
+
+// checkSession - check session of the user in the context, refresh it if ttl is not zero
+func checkSession(ctx context.Context, store SessionStore, ttl time.Duration) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || len(p.SessionKey) == 0 {
+		return sessionError()
+	}
+	var s Session
+	var err error
+	if ttl > 0 {
+		s, err = RefreshSession(ctx, store, p.SessionKey, ttl)
+	} else {
+		s, err = store.Get(ctx, p.SessionKey)
+	}
+	if err != nil {
+		GetLogger().Debug(ctx, "session rejected", "error", err)
+		return sessionError()
+	}
+	if s.UserID != p.ID {
+		return sessionError()
+	}
+	return nil
+}
+
+// SessionMiddleware - reject request if session of the user is revoked or expired.
+// ttl - if not zero session expiry is moved on each request. Must be placed after ParseHeaderMiddleware
+func SessionMiddleware(store SessionStore, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := checkSession(c.Request.Context(), store, ttl); err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+// SessionHTTPMiddleware - the same as SessionMiddleware for net/http. Must be wrapped by ParseHTTPHeaderMiddleware
+func SessionHTTPMiddleware(store SessionStore, ttl time.Duration, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := checkSession(r.Context(), store, ttl); err != nil {
+			writeJSON(w, http.StatusUnauthorized, err)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Таблицы LocalCache для хранения сессий
+const (
+	sessionsTable uint32 = iota
+	userSessionsTable
+)
+
+// localSweepInterval - как часто хранилища в памяти удаляют истекшие записи
+const localSweepInterval = time.Minute
+
+// LocalSessionStore - хранилище сессий в памяти процесса на основе LocalCache.
+// Истекшие сессии удаляются при сохранении новых, не чаще localSweepInterval
+type LocalSessionStore struct {
+	cache     *LocalCache
+	mt        sync.Mutex // защищает списки сессий пользователей
+	lastSweep time.Time
+}
+
+// NewLocalSessionStore - create in memory session store
+func NewLocalSessionStore() *LocalSessionStore {
+	return &LocalSessionStore{cache: GetNewNamedCache("sessions", 0, sessionsTable, userSessionsTable)}
+}
+
+func (ls *LocalSessionStore) userKeys(userID uint32) []SessionKey {
+	keys, _ := ls.cache.GetItem(userSessionsTable, userID).([]SessionKey)
+	return keys
+}
+
+// sweep - remove expired sessions and their keys from the lists of users. Called with ls.mt locked
+func (ls *LocalSessionStore) sweep(now time.Time) {
+	ls.lastSweep = now
+	users := make(map[uint32]struct{})
+	ls.cache.Sweep(sessionsTable, func(key, value interface{}) bool {
+		s, ok := value.(Session)
+		if ok && !s.ExpireDate.After(now) {
+			users[s.UserID] = struct{}{}
+			return true
+		}
+		return !ok
+	})
+	for userID := range users {
+		keys := ls.userKeys(userID)
+		active := make([]SessionKey, 0, len(keys))
+		for _, k := range keys {
+			if ls.cache.GetItem(sessionsTable, k) != nil {
+				active = append(active, k)
+			}
+		}
+		if len(active) == 0 {
+			ls.cache.DeleteItem(userSessionsTable, userID)
+		} else {
+			ls.cache.StoreItem(userSessionsTable, userID, active)
+		}
+	}
+}
+
+func (ls *LocalSessionStore) Save(ctx context.Context, s Session) error {
+	ls.mt.Lock()
+	defer ls.mt.Unlock()
+	if now := time.Now(); now.Sub(ls.lastSweep) >= localSweepInterval {
+		ls.sweep(now)
+	}
+	ls.cache.StoreItem(sessionsTable, s.Key, s)
+	keys := ls.userKeys(s.UserID)
+	for _, k := range keys {
+		if k == s.Key {
+			return nil
+		}
+	}
+	updated := make([]SessionKey, len(keys), len(keys)+1)
+	copy(updated, keys)
+	ls.cache.StoreItem(userSessionsTable, s.UserID, append(updated, s.Key))
+	return nil
+}
+
+func (ls *LocalSessionStore) Get(ctx context.Context, key SessionKey) (Session, error) {
+	s, ok := ls.cache.GetItem(sessionsTable, key).(Session)
+	if !ok || !s.ExpireDate.After(time.Now()) {
+		return Session{}, Errors["badSession"]
+	}
+	return s, nil
+}
+
+func (ls *LocalSessionStore) Refresh(ctx context.Context, key SessionKey, ttl time.Duration) (Session, error) {
+	ls.mt.Lock()
+	defer ls.mt.Unlock()
+	s, err := ls.Get(ctx, key)
+	if err != nil {
+		return s, err
+	}
+	s.LastActivity = time.Now()
+	s.ExpireDate = s.LastActivity.Add(ttl)
+	ls.cache.StoreItem(sessionsTable, s.Key, s)
+	return s, nil
+}
+
+func (ls *LocalSessionStore) Revoke(ctx context.Context, key SessionKey) error {
+	ls.mt.Lock()
+	defer ls.mt.Unlock()
+	s, ok := ls.cache.GetItem(sessionsTable, key).(Session)
+	if !ok {
+		return nil
+	}
+	ls.cache.DeleteItem(sessionsTable, key)
+	keys := ls.userKeys(s.UserID)
+	updated := make([]SessionKey, 0, len(keys))
+	for _, k := range keys {
+		if k != key {
+			updated = append(updated, k)
+		}
+	}
+	ls.cache.StoreItem(userSessionsTable, s.UserID, updated)
+	return nil
+}
+
+func (ls *LocalSessionStore) RevokeAll(ctx context.Context, userID uint32) error {
+	ls.mt.Lock()
+	defer ls.mt.Unlock()
+	for _, k := range ls.userKeys(userID) {
+		ls.cache.DeleteItem(sessionsTable, k)
+	}
+	ls.cache.DeleteItem(userSessionsTable, userID)
+	return nil
+}
+
+// List - active sessions of the user. Expired sessions are removed from the store
+func (ls *LocalSessionStore) List(ctx context.Context, userID uint32) ([]Session, error) {
+	ls.mt.Lock()
+	defer ls.mt.Unlock()
+	keys := ls.userKeys(userID)
+	res := make([]Session, 0, len(keys))
+	active := make([]SessionKey, 0, len(keys))
+	now := time.Now()
+	for _, k := range keys {
+		s, ok := ls.cache.GetItem(sessionsTable, k).(Session)
+		if !ok || !s.ExpireDate.After(now) {
+			ls.cache.DeleteItem(sessionsTable, k)
+			continue
+		}
+		res = append(res, s)
+		active = append(active, k)
+	}
+	ls.cache.StoreItem(userSessionsTable, userID, active)
+	return res, nil
+}
@@ -0,0 +1,112 @@
+package golang
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLocalSessionRefreshAfterRevoke(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalSessionStore()
+	user := User{ID: 1}
+	s, err := CreateSession(ctx, store, &user, nil, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refreshed, err := RefreshSession(ctx, store, s.Key, time.Hour)
+	if err != nil || !refreshed.ExpireDate.After(s.ExpireDate) {
+		t.Fatalf("refresh: %+v, %v", refreshed, err)
+	}
+	if err = store.Revoke(ctx, s.Key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = RefreshSession(ctx, store, s.Key, time.Hour); !errors.Is(err, Errors["badSession"]) {
+		t.Fatalf("revoked session refreshed: %v", err)
+	}
+	if _, err = store.Get(ctx, s.Key); err == nil {
+		t.Fatal("revoked session restored")
+	}
+}
+
+func TestLocalSessionConcurrentRevokeAll(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalSessionStore()
+	user := User{ID: 2}
+	var keys []SessionKey
+	for i := 0; i < 10; i++ {
+		s, err := CreateSession(ctx, store, &user, nil, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, s.Key)
+	}
+	var wg sync.WaitGroup
+	for _, k := range keys {
+		wg.Add(1)
+		go func(k SessionKey) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				RefreshSession(ctx, store, k, time.Minute)
+			}
+		}(k)
+	}
+	store.RevokeAll(ctx, user.ID)
+	wg.Wait()
+	for _, k := range keys {
+		if _, err := store.Get(ctx, k); err == nil {
+			t.Fatalf("session %s resurrected", k)
+		}
+	}
+	if list, _ := store.List(ctx, user.ID); len(list) != 0 {
+		t.Fatalf("sessions after RevokeAll: %d", len(list))
+	}
+}
+
+func TestLocalSessionSweep(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalSessionStore()
+	expired := Session{Key: "expired", UserID: 5, ExpireDate: time.Now().Add(-time.Second)}
+	active := Session{Key: "active", UserID: 5, ExpireDate: time.Now().Add(time.Minute)}
+	other := Session{Key: "other", UserID: 6, ExpireDate: time.Now().Add(-time.Second)}
+	for _, s := range []Session{expired, other, active} {
+		store.Save(ctx, s)
+	}
+	store.mt.Lock()
+	store.lastSweep = time.Time{}
+	store.mt.Unlock()
+	store.Save(ctx, Session{Key: "new", UserID: 7, ExpireDate: time.Now().Add(time.Minute)})
+
+	for _, k := range []SessionKey{expired.Key, other.Key} {
+		if store.cache.GetItem(sessionsTable, k) != nil {
+			t.Errorf("expired session %s is kept", k)
+		}
+	}
+	if keys := store.userKeys(5); len(keys) != 1 || keys[0] != active.Key {
+		t.Errorf("sessions of user: %v", keys)
+	}
+	if store.cache.GetItem(userSessionsTable, other.UserID) != nil {
+		t.Error("empty session list is kept")
+	}
+}
+
+func TestSessionMiddlewareCheck(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalSessionStore()
+	user := User{ID: 3}
+	s, _ := CreateSession(ctx, store, &user, nil, time.Minute)
+	reqCtx := context.WithValue(ctx, PrincipalKey, Principal{ID: 3, SessionKey: s.Key})
+	if err := checkSession(reqCtx, store, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	other := context.WithValue(ctx, PrincipalKey, Principal{ID: 4, SessionKey: s.Key})
+	if err := checkSession(other, store, 0); err == nil {
+		t.Fatal("session of other user accepted")
+	}
+	store.Revoke(ctx, s.Key)
+	if err := checkSession(reqCtx, store, time.Minute); err == nil {
+		t.Fatal("revoked session accepted")
+	}
+}
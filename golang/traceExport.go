@@ -0,0 +1,199 @@
+package golang
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// JSONLinesSpanExporter - write spans as JSON lines to the file with rotation by size
+type JSONLinesSpanExporter struct {
+	lines *RotatedFile
+}
+
+// NewJSONLinesSpanExporter - create exporter to file path. See OpenRotatedFile for maxSize and maxBackups
+func NewJSONLinesSpanExporter(path string, maxSize int64, maxBackups int) (*JSONLinesSpanExporter, error) {
+	f, err := OpenRotatedFile(path, maxSize, maxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLinesSpanExporter{lines: f}, nil
+}
+
+func (e *JSONLinesSpanExporter) ExportSpans(ctx context.Context, spans []SpanData) error {
+	for i := range spans {
+		data, err := json.Marshal(&spans[i])
+		if err != nil {
+			return err
+		}
+		if err = e.lines.WriteLine(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *JSONLinesSpanExporter) Close() error {
+	return e.lines.Close()
+}
+
+// OTLPHTTPExporter - send spans to the OpenTelemetry collector by OTLP/HTTP protocol with JSON encoding
+type OTLPHTTPExporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+// NewOTLPHTTPExporter - endpoint is full url of the collector, for example http://localhost:4318/v1/traces
+// headers - additional headers of each request (authorization and so on), can be nil
+func NewOTLPHTTPExporter(endpoint string, headers map[string]string, timeout time.Duration) *OTLPHTTPExporter {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &OTLPHTTPExporter{endpoint: endpoint, headers: headers, client: &http.Client{Timeout: timeout}}
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	TraceState        string          `json:"traceState,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpScopeSpans struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpResourceSpans struct {
+	Resource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	} `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+func toOTLPValue(v interface{}) otlpValue {
+	switch val := v.(type) {
+	case string:
+		return otlpValue{StringValue: &val}
+	case bool:
+		return otlpValue{BoolValue: &val}
+	case int:
+		s := strconv.FormatInt(int64(val), 10)
+		return otlpValue{IntValue: &s}
+	case int64:
+		s := strconv.FormatInt(val, 10)
+		return otlpValue{IntValue: &s}
+	case uint32:
+		s := strconv.FormatUint(uint64(val), 10)
+		return otlpValue{IntValue: &s}
+	case uint64:
+		s := strconv.FormatUint(val, 10)
+		return otlpValue{IntValue: &s}
+	case float64:
+		return otlpValue{DoubleValue: &val}
+	}
+	s := fmt.Sprint(v)
+	return otlpValue{StringValue: &s}
+}
+
+func toOTLPSpan(s SpanData) otlpSpan {
+	res := otlpSpan{
+		TraceID:           s.TraceID,
+		SpanID:            s.SpanID,
+		ParentSpanID:      s.ParentSpanID,
+		TraceState:        s.TraceState,
+		Name:              s.Name,
+		Kind:              s.Kind,
+		StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+	}
+	// код 0 (Unset) - спан не сообщил о результате
+	switch {
+	case s.IsError:
+		res.Status = otlpStatus{Code: 2, Message: s.StatusMsg}
+	case s.IsOK:
+		res.Status = otlpStatus{Code: 1}
+	}
+	for k, v := range s.Attributes {
+		res.Attributes = append(res.Attributes, otlpAttribute{Key: k, Value: toOTLPValue(v)})
+	}
+	return res
+}
+
+// ExportSpans - group spans by service and send them in one request
+func (e *OTLPHTTPExporter) ExportSpans(ctx context.Context, spans []SpanData) error {
+	var req otlpRequest
+	byService := make(map[string]int)
+	for i := range spans {
+		idx, ok := byService[spans[i].Service]
+		if !ok {
+			var rs otlpResourceSpans
+			rs.Resource.Attributes = []otlpAttribute{{Key: "service.name", Value: toOTLPValue(spans[i].Service)}}
+			rs.ScopeSpans = make([]otlpScopeSpans, 1)
+			rs.ScopeSpans[0].Scope.Name = "github.com/blabu/egeonLib/golang"
+			req.ResourceSpans = append(req.ResourceSpans, rs)
+			idx = len(req.ResourceSpans) - 1
+			byService[spans[i].Service] = idx
+		}
+		scope := &req.ResourceSpans[idx].ScopeSpans[0]
+		scope.Spans = append(scope.Spans, toOTLPSpan(spans[i]))
+	}
+	body, err := json.Marshal(&req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		httpReq.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("collector answer %d: %s", resp.StatusCode, string(data))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,468 @@
+package golang
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Заголовки W3C Trace Context
+const (
+	TraceparentHeaderKey = "traceparent"
+	TracestateHeaderKey  = "tracestate"
+)
+
+// RequestIDSpanAttribute - attribute of the span with request ID
+const RequestIDSpanAttribute = "egeon.request_id"
+
+// SpanKind - тип спана (совпадает с OTLP)
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+type spanKeyType string
+
+// SpanKey - ключ, по которому в контексте будет сохранен текущий спан
+var SpanKey spanKeyType
+
+// TraceID - идентификатор трассировки
+type TraceID [16]byte
+
+// SpanID - идентификатор спана
+type SpanID [8]byte
+
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+func (t TraceID) IsValid() bool  { return t != TraceID{} }
+func (s SpanID) String() string  { return hex.EncodeToString(s[:]) }
+func (s SpanID) IsValid() bool   { return s != SpanID{} }
+
+// SpanContext - часть спана, которая передается между сервисами
+type SpanContext struct {
+	TraceID    TraceID
+	SpanID     SpanID
+	Sampled    bool
+	TraceState string
+}
+
+// IsValid - true if trace and span IDs are not zero
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent - value of traceparent header
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+var errBadTraceparent = errors.New("malformed traceparent")
+
+// lowerHex - string contains only lower case hex digits (W3C Trace Context forbids upper case)
+func lowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < '0' || s[i] > '9') && (s[i] < 'a' || s[i] > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseTraceparent - parse value of traceparent header (version 00)
+func ParseTraceparent(value string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, errBadTraceparent
+	}
+	for _, part := range parts[:4] {
+		if !lowerHex(part) {
+			return sc, errBadTraceparent
+		}
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return sc, errBadTraceparent
+	}
+	var flags [1]byte
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return sc, errBadTraceparent
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return sc, errBadTraceparent
+	}
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return sc, errBadTraceparent
+	}
+	if !sc.IsValid() {
+		return sc, errBadTraceparent
+	}
+	sc.Sampled = flags[0]&1 == 1
+	return sc, nil
+}
+
+// ExtractSpanContext - read traceparent and tracestate from headers
+func ExtractSpanContext(h http.Header) (SpanContext, bool) {
+	sc, err := ParseTraceparent(h.Get(TraceparentHeaderKey))
+	if err != nil {
+		return SpanContext{}, false
+	}
+	sc.TraceState = h.Get(TracestateHeaderKey)
+	return sc, true
+}
+
+// InjectSpanContext - write traceparent and tracestate to headers
+func InjectSpanContext(h http.Header, sc SpanContext) {
+	if !sc.IsValid() {
+		return
+	}
+	h.Set(TraceparentHeaderKey, sc.Traceparent())
+	if len(sc.TraceState) != 0 {
+		h.Set(TracestateHeaderKey, sc.TraceState)
+	}
+}
+
+// SpanData - завершенный спан, который отправляется в экспортер
+type SpanData struct {
+	Name         string                 `json:"name"`
+	Service      string                 `json:"service"`
+	Kind         SpanKind               `json:"kind"`
+	TraceID      string                 `json:"traceId"`
+	SpanID       string                 `json:"spanId"`
+	ParentSpanID string                 `json:"parentSpanId,omitempty"`
+	TraceState   string                 `json:"traceState,omitempty"`
+	Start        time.Time              `json:"start"`
+	End          time.Time              `json:"end"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	IsError      bool                   `json:"isError,omitempty"`
+	IsOK         bool                   `json:"isOk,omitempty"` // статус явно установлен в OK, иначе он не задан
+	StatusMsg    string                 `json:"statusMessage,omitempty"`
+}
+
+// Span - операция в рамках трассировки
+type Span struct {
+	tracer *Tracer
+	sc     SpanContext
+	mt     sync.Mutex
+	data   SpanData
+	ended  bool
+}
+
+// SpanContext - context of the span to propagate it
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// SetAttribute - add attribute to the span. Safe for nil span
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mt.Lock()
+	if s.data.Attributes == nil {
+		s.data.Attributes = make(map[string]interface{})
+	}
+	s.data.Attributes[key] = value
+	s.mt.Unlock()
+}
+
+// SetError - mark span as failed
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mt.Lock()
+	s.data.IsError, s.data.IsOK = true, false
+	s.data.StatusMsg = err.Error()
+	s.mt.Unlock()
+}
+
+// SetOK - mark span as explicitly successful. Safe for nil span
+func (s *Span) SetOK() {
+	if s == nil {
+		return
+	}
+	s.mt.Lock()
+	s.data.IsError, s.data.IsOK = false, true
+	s.data.StatusMsg = ""
+	s.mt.Unlock()
+}
+
+// End - finish span and send it to the exporter. Safe for nil span
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mt.Lock()
+	if s.ended {
+		s.mt.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	s.mt.Unlock()
+	if s.sc.Sampled {
+		s.tracer.enqueue(data)
+	}
+}
+
+// SpanFromContext - current span in the context or nil
+func SpanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(SpanKey).(*Span)
+	return s
+}
+
+// SpanExporter - destination of finished spans (file, OTLP collector and so on)
+type SpanExporter interface {
+	ExportSpans(ctx context.Context, spans []SpanData) error
+}
+
+// TracerOptions - options of the batch export of spans
+type TracerOptions struct {
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// Tracer - create spans and export them by batches in background
+type Tracer struct {
+	service  string
+	exporter SpanExporter
+	opt      TracerOptions
+	queue    chan SpanData
+	done     chan struct{}
+	mt       sync.RWMutex
+	closed   bool
+	dropped  uint64
+}
+
+// NewTracer - create tracer of the service and start background export to exporter
+func NewTracer(service string, exporter SpanExporter, opt TracerOptions) *Tracer {
+	if opt.QueueSize <= 0 {
+		opt.QueueSize = 2048
+	}
+	if opt.BatchSize <= 0 {
+		opt.BatchSize = 128
+	}
+	if opt.FlushInterval <= 0 {
+		opt.FlushInterval = 5 * time.Second
+	}
+	t := &Tracer{
+		service:  service,
+		exporter: exporter,
+		opt:      opt,
+		queue:    make(chan SpanData, opt.QueueSize),
+		done:     make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+func newIDs(traceID *TraceID, spanID *SpanID) error {
+	if traceID != nil {
+		if _, err := rand.Read(traceID[:]); err != nil {
+			return err
+		}
+	}
+	_, err := rand.Read(spanID[:])
+	return err
+}
+
+// Start - start new span as child of the span in ctx (or of remote parent), return context with the new span
+// Safe for nil tracer (returns nil span). If random IDs can not be generated span is not started (nil span)
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+	var parent SpanContext
+	if p := SpanFromContext(ctx); p != nil {
+		parent = p.sc
+	} else if remote, ok := ctx.Value(remoteSpanKey).(SpanContext); ok {
+		parent = remote
+	}
+	s := &Span{tracer: t}
+	var err error
+	if parent.IsValid() {
+		s.sc = SpanContext{TraceID: parent.TraceID, Sampled: parent.Sampled, TraceState: parent.TraceState}
+		err = newIDs(nil, &s.sc.SpanID)
+		s.data.ParentSpanID = parent.SpanID.String()
+	} else {
+		s.sc = SpanContext{Sampled: true}
+		err = newIDs(&s.sc.TraceID, &s.sc.SpanID)
+	}
+	if err != nil {
+		GetLogger().Error(ctx, "span IDs generation failed", "span", name, "error", err)
+		return ctx, nil
+	}
+	s.data.Name = name
+	s.data.Service = t.service
+	s.data.Kind = kind
+	s.data.TraceID = s.sc.TraceID.String()
+	s.data.SpanID = s.sc.SpanID.String()
+	s.data.TraceState = s.sc.TraceState
+	s.data.Start = time.Now()
+	return context.WithValue(ctx, SpanKey, s), s
+}
+
+type remoteSpanKeyType string
+
+var remoteSpanKey remoteSpanKeyType
+
+// ContextWithRemoteSpan - save span context received from another service as parent for new spans
+func ContextWithRemoteSpan(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteSpanKey, sc)
+}
+
+func (t *Tracer) enqueue(data SpanData) {
+	t.mt.RLock()
+	defer t.mt.RUnlock()
+	if t.closed {
+		atomic.AddUint64(&t.dropped, 1)
+		return
+	}
+	select {
+	case t.queue <- data:
+	default:
+		atomic.AddUint64(&t.dropped, 1)
+	}
+}
+
+// Dropped - count of spans dropped because of the full queue or closed tracer
+func (t *Tracer) Dropped() uint64 {
+	return atomic.LoadUint64(&t.dropped)
+}
+
+// Close - export all spans from the queue and stop background goroutine
+func (t *Tracer) Close() {
+	t.mt.Lock()
+	if t.closed {
+		t.mt.Unlock()
+		return
+	}
+	t.closed = true
+	close(t.queue)
+	t.mt.Unlock()
+	<-t.done
+}
+
+func (t *Tracer) flush(batch []SpanData) {
+	if len(batch) == 0 {
+		return
+	}
+	if err := t.exporter.ExportSpans(context.Background(), batch); err != nil {
+		GetLogger().Error(context.Background(), "spans export failed", "spans", len(batch), "error", err)
+	}
+}
+
+func (t *Tracer) run() {
+	defer close(t.done)
+	ticker := time.NewTicker(t.opt.FlushInterval)
+	defer ticker.Stop()
+	batch := make([]SpanData, 0, t.opt.BatchSize)
+	for {
+		select {
+		case s, ok := <-t.queue:
+			if !ok {
+				t.flush(batch)
+				return
+			}
+			batch = append(batch, s)
+			if len(batch) >= t.opt.BatchSize {
+				t.flush(batch)
+				batch = make([]SpanData, 0, t.opt.BatchSize)
+			}
+		case <-ticker.C:
+			t.flush(batch)
+			batch = make([]SpanData, 0, t.opt.BatchSize)
+		}
+	}
+}
+
+var libTracer atomic.Value
+
+type tracerHolder struct {
+	*Tracer
+}
+
+// SetTracer - set tracer used by DoRequest. nil disables tracing of outgoing requests
+func SetTracer(t *Tracer) {
+	libTracer.Store(tracerHolder{t})
+}
+
+// GetTracer - tracer of the library or nil
+func GetTracer() *Tracer {
+	h, _ := libTracer.Load().(tracerHolder)
+	return h.Tracer
+}
+
+// startServerSpan - start span of incoming request with remote parent from headers
+func (t *Tracer) startServerSpan(r *http.Request, name string) (context.Context, *Span) {
+	ctx := r.Context()
+	if sc, ok := ExtractSpanContext(r.Header); ok {
+		ctx = ContextWithRemoteSpan(ctx, sc)
+	}
+	ctx, span := t.Start(ctx, name, SpanKindServer)
+	span.SetAttribute("http.method", r.Method)
+	span.SetAttribute("http.target", r.URL.RequestURI())
+	return ctx, span
+}
+
+func finishServerSpan(span *Span, r *http.Request, status int) {
+	reqID, _ := r.Context().Value(RequestID).(string)
+	if len(reqID) == 0 {
+		reqID = r.Header.Get(RequestIDHeaderKey)
+	}
+	if len(reqID) != 0 {
+		span.SetAttribute(RequestIDSpanAttribute, reqID)
+	}
+	span.SetAttribute("http.status_code", status)
+	if status >= http.StatusInternalServerError {
+		span.SetError(errors.New(http.StatusText(status)))
+	}
+	span.End()
+}
+
+// TracingMiddleware - gin middleware that start server span for each request
+// Place it before ParseHeaderMiddleware to trace all request
+func TracingMiddleware(t *Tracer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.FullPath()
+		if len(name) == 0 {
+			name = "unmatched"
+		}
+		ctx, span := t.startServerSpan(c.Request, c.Request.Method+" "+name)
+		span.SetAttribute("http.route", name)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		finishServerSpan(span, c.Request, c.Writer.Status())
+	}
+}
+
+// TracingHTTPMiddleware - net/http middleware that start server span for each request. route - route template of the handler
+func TracingHTTPMiddleware(t *Tracer, route string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := t.startServerSpan(r, r.Method+" "+route)
+		span.SetAttribute("http.route", route)
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		r = r.WithContext(ctx)
+		handler.ServeHTTP(sw, r)
+		finishServerSpan(span, r, sw.status)
+	})
+}
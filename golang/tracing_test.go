@@ -0,0 +1,174 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	retry "github.com/hashicorp/go-retryablehttp"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	cases := []struct {
+		value   string
+		ok      bool
+		sampled bool
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true, false},
+		{" 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01 ", true, true},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", true, true},
+		{"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00F067AA0BA902B7-01", false, false},
+		{"0A-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0F", false, false},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e47z6-00f067aa0ba902b7-01", false, false},
+		{"", false, false},
+	}
+	for _, c := range cases {
+		sc, err := ParseTraceparent(c.value)
+		if (err == nil) != c.ok || c.ok && sc.Sampled != c.sampled {
+			t.Errorf("%q: %+v %v", c.value, sc, err)
+			continue
+		}
+		if value := strings.TrimSpace(c.value); c.ok && strings.HasPrefix(value, "00-") && sc.Traceparent() != value {
+			t.Errorf("%q: round trip %s", c.value, sc.Traceparent())
+		}
+	}
+}
+
+// collector - stand-in OTLP/HTTP collector
+type collector struct {
+	mt    sync.Mutex
+	spans []otlpSpan
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req otlpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.mt.Lock()
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+	c.mt.Unlock()
+}
+
+func TestTracerExportToCollector(t *testing.T) {
+	col := &collector{}
+	colSrv := httptest.NewServer(col)
+	defer colSrv.Close()
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer svc.Close()
+
+	tracer := NewTracer("test", NewOTLPHTTPExporter(colSrv.URL, nil, time.Second), TracerOptions{FlushInterval: time.Hour})
+	prev := GetTracer()
+	SetTracer(tracer)
+	defer SetTracer(prev)
+
+	u, _ := url.Parse(svc.URL)
+	client := retry.NewClient()
+	client.Logger = nil
+	ctx, root := tracer.Start(context.Background(), "root", SpanKindInternal)
+	if _, err := DoRequest(ctx, client, http.MethodGet, *u, nil); err != nil {
+		t.Fatal(err)
+	}
+	editorErr := errors.New("editor failed")
+	failing := func(ctx context.Context, req *retry.Request) error { return editorErr }
+	if _, err := DoRequest(ctx, client, http.MethodGet, *u, nil, failing); err != editorErr {
+		t.Fatalf("editor error: %v", err)
+	}
+	root.End()
+	tracer.Close()
+	root.End()
+
+	if len(col.spans) != 3 {
+		t.Fatalf("collector got %d spans", len(col.spans))
+	}
+	ok, failed, parent := col.spans[0], col.spans[1], col.spans[2]
+	if ok.Status.Code != 0 || ok.ParentSpanID != parent.SpanID || ok.TraceID != parent.TraceID {
+		t.Errorf("request span %+v", ok)
+	}
+	if failed.Status.Code != 2 || failed.Status.Message != editorErr.Error() {
+		t.Errorf("failed editor span %+v", failed)
+	}
+	if tracer.Dropped() != 0 {
+		t.Errorf("dropped %d", tracer.Dropped())
+	}
+	_, span := tracer.Start(context.Background(), "late", SpanKindInternal)
+	span.End()
+	if tracer.Dropped() != 1 {
+		t.Errorf("span of closed tracer is not dropped")
+	}
+}
+
+// spanRecorder - exporter that keeps exported spans
+type spanRecorder struct {
+	mt    sync.Mutex
+	spans []SpanData
+}
+
+func (r *spanRecorder) ExportSpans(ctx context.Context, spans []SpanData) error {
+	r.mt.Lock()
+	r.spans = append(r.spans, spans...)
+	r.mt.Unlock()
+	return nil
+}
+
+func TestTracingHTTPMiddlewareRemoteParent(t *testing.T) {
+	rec := &spanRecorder{}
+	tracer := NewTracer("test", rec, TracerOptions{FlushInterval: time.Hour})
+	var inner SpanContext
+	handler := TracingHTTPMiddleware(tracer, "/items", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inner = SpanFromContext(r.Context()).SpanContext()
+	}))
+	const parent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set("traceparent", parent)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	tracer.Close()
+
+	if len(rec.spans) != 1 {
+		t.Fatalf("exported %d spans", len(rec.spans))
+	}
+	span := rec.spans[0]
+	if span.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || span.ParentSpanID != "00f067aa0ba902b7" || span.Kind != SpanKindServer {
+		t.Errorf("server span %+v", span)
+	}
+	if inner.TraceID.String() != span.TraceID || inner.SpanID.String() != span.SpanID {
+		t.Errorf("handler context span %s, exported %s/%s", inner.Traceparent(), span.TraceID, span.SpanID)
+	}
+	if st := toOTLPSpan(span).Status; st.Code != 0 {
+		t.Errorf("status of span without explicit result %+v", st)
+	}
+}
+
+func TestOTLPSpanStatus(t *testing.T) {
+	tracer := NewTracer("test", &spanRecorder{}, TracerOptions{})
+	defer tracer.Close()
+	_, span := tracer.Start(context.Background(), "op", SpanKindInternal)
+	span.SetOK()
+	if st := toOTLPSpan(span.data).Status; st.Code != 1 {
+		t.Errorf("ok span status %+v", st)
+	}
+	span.SetError(errors.New("failed"))
+	if st := toOTLPSpan(span.data).Status; st.Code != 2 || st.Message != "failed" {
+		t.Errorf("failed span status %+v", st)
+	}
+}
@@ -0,0 +1,342 @@
+package golang
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Operation - операция, для которой выполняется проверка DTO
+type Operation uint8
+
+const (
+	OpAny    Operation = iota // правило проверяется всегда
+	OpCreate                  // правило проверяется только при создании
+	OpUpdate                  // правило проверяется только при обновлении
+)
+
+// Rule - правило проверки значения поля. path - путь к полю по json тегам (address.lat).
+// Все правила кроме Required пропускают пустые (нулевые) значения
+type Rule func(path string, value interface{}, op Operation) []FieldError
+
+// FieldRule - правила для поля структуры. Field - имя поля в json.
+// When - условие по всему объекту (структура, не указатель), nil - правило проверяется всегда
+type FieldRule struct {
+	Field string
+	Rules []Rule
+	On    Operation
+	When  func(obj interface{}) bool
+}
+
+// RuleSet - декларативный набор правил для структуры.
+// Создавайте через NewRuleSet или MustRuleSet, чтобы имена полей были проверены заранее
+type RuleSet []FieldRule
+
+// NewRuleSet - check that every field of rules is the json field of the sample struct
+func NewRuleSet(sample interface{}, rules RuleSet) (RuleSet, error) {
+	t := reflect.TypeOf(sample)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("rule set sample %T is not a struct", sample)
+	}
+	indexes := fieldIndexes(t)
+	for _, fr := range rules {
+		if _, ok := indexes[fr.Field]; !ok {
+			return nil, fmt.Errorf("field %s is not found in %s", fr.Field, t)
+		}
+	}
+	return rules, nil
+}
+
+// MustRuleSet - NewRuleSet for package level rule sets, panics on error (like regexp.MustCompile)
+func MustRuleSet(sample interface{}, rules RuleSet) RuleSet {
+	rs, err := NewRuleSet(sample, rules)
+	if err != nil {
+		panic(err)
+	}
+	return rs
+}
+
+// jsonFields - индексы полей структур по имени в json
+var jsonFields sync.Map
+
+func fieldIndexes(t reflect.Type) map[string]int {
+	if m, ok := jsonFields.Load(t); ok {
+		return m.(map[string]int)
+	}
+	m := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if len(name) == 0 {
+			name = f.Name
+		}
+		if name != "-" {
+			m[name] = i
+		}
+	}
+	jsonFields.Store(t, m)
+	return m
+}
+
+func joinPath(prefix, field string) string {
+	if len(prefix) == 0 {
+		return field
+	}
+	return prefix + "." + field
+}
+
+// Check - apply rules of the operation to obj (struct or pointer to struct). Fields are prefixed by path.
+// Rule for unknown field (rule set is not created by NewRuleSet) is reported as error of this field
+func (rs RuleSet) Check(path string, obj interface{}, op Operation) []FieldError {
+	res, err := rs.check(path, obj, op)
+	if err != nil {
+		return append(res, FieldError{Field: path, Message: err.Error()})
+	}
+	return res
+}
+
+func (rs RuleSet) check(path string, obj interface{}, op Operation) ([]FieldError, error) {
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	if v.Kind() != reflect.Struct {
+		return []FieldError{{Field: path, Message: Errors["badType"].Error()}}, nil
+	}
+	indexes := fieldIndexes(v.Type())
+	var res []FieldError
+	for _, fr := range rs {
+		if fr.On != OpAny && fr.On != op {
+			continue
+		}
+		i, ok := indexes[fr.Field]
+		if !ok {
+			return res, fmt.Errorf("field %s is not found in %s", fr.Field, v.Type())
+		}
+		if fr.When != nil && !fr.When(v.Interface()) {
+			continue
+		}
+		value := v.Field(i).Interface()
+		for _, r := range fr.Rules {
+			if errs := r(joinPath(path, fr.Field), value, op); len(errs) != 0 {
+				res = append(res, errs...)
+				break // для поля достаточно первой ошибки
+			}
+		}
+	}
+	return res, nil
+}
+
+// Validate - check obj and return NewValidateError with all failed fields.
+// Rule set with unknown field is an error of the service, not of the input, so it returns InternalError
+func (rs RuleSet) Validate(obj interface{}, op Operation) error {
+	errs, err := rs.check("", obj, op)
+	if err != nil {
+		return EgeonError{Code: InternalError, Description: err.Error()}
+	}
+	if len(errs) != 0 {
+		return NewValidateError(errs...)
+	}
+	return nil
+}
+
+func isZero(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+func fieldError(path, format string, args ...interface{}) []FieldError {
+	return []FieldError{{Field: path, Message: fmt.Sprintf(format, args...)}}
+}
+
+// Required - value must not be empty
+func Required() Rule {
+	return func(path string, value interface{}, op Operation) []FieldError {
+		if isZero(value) {
+			return fieldError(path, "обов'язкове поле")
+		}
+		return nil
+	}
+}
+
+// Length - length of the string (in symbols) or count of the slice elements must be in [min, max]. max = 0 - unlimited
+func Length(min, max int) Rule {
+	return func(path string, value interface{}, op Operation) []FieldError {
+		if isZero(value) {
+			return nil
+		}
+		var l int
+		if s, ok := value.(string); ok {
+			l = utf8.RuneCountInString(s)
+		} else if v := reflect.ValueOf(value); v.Kind() == reflect.Slice || v.Kind() == reflect.Map {
+			l = v.Len()
+		} else {
+			return fieldError(path, Errors["badType"].Error())
+		}
+		if l < min || max > 0 && l > max {
+			if max > 0 {
+				return fieldError(path, "довжина має бути від %d до %d", min, max)
+			}
+			return fieldError(path, "довжина має бути не менше %d", min)
+		}
+		return nil
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// Range - number must be in [min, max]
+func Range(min, max float64) Rule {
+	return func(path string, value interface{}, op Operation) []FieldError {
+		if isZero(value) {
+			return nil
+		}
+		f, ok := toFloat(value)
+		if !ok {
+			return fieldError(path, Errors["badType"].Error())
+		}
+		if f < min || f > max {
+			return fieldError(path, "значення має бути в межах від %s до %s",
+				strconv.FormatFloat(min, 'f', -1, 64), strconv.FormatFloat(max, 'f', -1, 64))
+		}
+		return nil
+	}
+}
+
+// Match - string must match regular expression
+func Match(re *regexp.Regexp) Rule {
+	return func(path string, value interface{}, op Operation) []FieldError {
+		if isZero(value) {
+			return nil
+		}
+		if s := fmt.Sprint(value); !re.MatchString(s) {
+			return fieldError(path, "не відповідає формату")
+		}
+		return nil
+	}
+}
+
+// OneOf - value must be one of values
+func OneOf(values ...string) Rule {
+	return func(path string, value interface{}, op Operation) []FieldError {
+		if isZero(value) {
+			return nil
+		}
+		s := fmt.Sprint(value)
+		for _, v := range values {
+			if s == v {
+				return nil
+			}
+		}
+		return fieldError(path, "допустимі значення: %s", strings.Join(values, ", "))
+	}
+}
+
+// Future - time must be after now
+func Future() Rule {
+	return func(path string, value interface{}, op Operation) []FieldError {
+		t, ok := value.(time.Time)
+		if !ok || t.IsZero() {
+			return nil
+		}
+		if !t.After(time.Now()) {
+			return fieldError(path, "дата має бути в майбутньому")
+		}
+		return nil
+	}
+}
+
+// Custom - custom rule, check returns error with description of the problem
+func Custom(check func(value interface{}) error) Rule {
+	return func(path string, value interface{}, op Operation) []FieldError {
+		if isZero(value) {
+			return nil
+		}
+		if err := check(value); err != nil {
+			return fieldError(path, err.Error())
+		}
+		return nil
+	}
+}
+
+// Email - string must be correct email (see NormalizeEmail)
+func Email() Rule {
+	return Custom(func(value interface{}) error {
+		_, err := NormalizeEmail(fmt.Sprint(value))
+		return err
+	})
+}
+
+// Phone - string must be correct phone number (see NormalizePhone)
+func Phone() Rule {
+	return Custom(func(value interface{}) error {
+		_, err := NormalizePhone(fmt.Sprint(value), DefaultPhoneRegion)
+		return err
+	})
+}
+
+// EDRPOU - number must be correct EDRPOU code of the company
+func EDRPOU() Rule {
+	return Custom(func(value interface{}) error {
+		if code, ok := value.(uint64); !ok || !ValidEDRPOU(code) {
+			return Errors["badCompanyCode"]
+		}
+		return nil
+	})
+}
+
+// Nested - check nested struct by rules. Empty struct is skipped (use Required to demand it).
+// Only OpAny rules are checked for nested struct, operation rules are applied to the root object
+func Nested(rules RuleSet) Rule {
+	return func(path string, value interface{}, op Operation) []FieldError {
+		if isZero(value) {
+			return nil
+		}
+		return rules.Check(path, value, OpAny)
+	}
+}
+
+// Each - check each element of the slice, path of the element is path[i]
+func Each(rules ...Rule) Rule {
+	return func(path string, value interface{}, op Operation) []FieldError {
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Slice {
+			return nil
+		}
+		var res []FieldError
+		for i := 0; i < v.Len(); i++ {
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+			item := v.Index(i).Interface()
+			for _, r := range rules {
+				if errs := r(itemPath, item, op); len(errs) != 0 {
+					res = append(res, errs...)
+					break
+				}
+			}
+		}
+		return res
+	}
+}
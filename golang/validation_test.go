@@ -0,0 +1,88 @@
+package golang
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRuleSetUnknownField(t *testing.T) {
+	rules := RuleSet{{Field: "missing", Rules: []Rule{Required()}}}
+	if _, err := NewRuleSet(Status{}, rules); err == nil {
+		t.Fatal("unknown field accepted")
+	}
+	if _, err := NewRuleSet(1, rules); err == nil {
+		t.Fatal("not a struct accepted")
+	}
+	if _, err := NewRuleSet(&Status{}, RuleSet{{Field: "name"}}); err != nil {
+		t.Fatal(err)
+	}
+	err := rules.Validate(&Status{}, OpAny)
+	var e EgeonError
+	if !errors.As(err, &e) || e.Code != InternalError {
+		t.Fatalf("got %v", err)
+	}
+	// вложенный набор с ошибкой не роняет проверку
+	nested := RuleSet{{Field: "status", Rules: []Rule{Nested(rules)}}}
+	if errs := nested.Check("", Comment{Status: Status{Name: "x"}}, OpAny); len(errs) != 1 || errs[0].Field != "status" {
+		t.Fatalf("nested errors %+v", errs)
+	}
+}
+
+func TestAddressRulesPostCode(t *testing.T) {
+	cases := []struct {
+		country, postCode string
+		valid             bool
+	}{
+		{"Україна", "01001", true},
+		{"UA", "1001", false},
+		{" ukraine ", "01001A", false},
+		{"Україна", "", true},
+		{"Poland", "00-950", true},
+		{"United Kingdom", "SW1A 1AA", true},
+		{"", "SW1A 1AA", true},
+	}
+	for _, c := range cases {
+		a := Address{City: "Київ", Country: c.country, PostCode: c.postCode}
+		err := a.Validate()
+		if (err == nil) != c.valid {
+			t.Errorf("%q %q: %v", c.country, c.postCode, err)
+		}
+	}
+}
+
+func TestRuleSetValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		fields []string
+	}{
+		{"valid company", (&Company{Name: "ПриватБанк", Code: 14360570}).Validate(), nil},
+		{"bad company", (&Company{Code: 14360571, Addr: Address{Lat: 91}}).Validate(), []string{"name", "code", "address.city", "address.lat"}},
+		{"update without id", (&Group{Name: "g"}).ValidateFor(OpUpdate), []string{"id"}},
+		{"role method", (&Role{Name: "r", URL: "/", Method: "GET2"}).Validate(), []string{"method"}},
+		{"user roles", (&User{Email: "a@egeon.ua", Roles: []Role{{ID: 1}, {Name: "admin"}}}).Validate(), []string{"roles[1].id"}},
+	}
+	for _, c := range cases {
+		var e EgeonError
+		if c.fields == nil {
+			if c.err != nil {
+				t.Errorf("%s: %v", c.name, c.err)
+			}
+			continue
+		}
+		if !errors.As(c.err, &e) || e.Code != ValidateError {
+			t.Errorf("%s: %v", c.name, c.err)
+			continue
+		}
+		got := e.FieldList()
+		if len(got) != len(c.fields) {
+			t.Errorf("%s: fields %+v", c.name, got)
+			continue
+		}
+		for i := range got {
+			if got[i].Field != c.fields[i] {
+				t.Errorf("%s: field %d %s, want %s", c.name, i, got[i].Field, c.fields[i])
+			}
+		}
+	}
+}